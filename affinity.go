@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// simulatedCPUs is how many CPUs the simulator runs, numbered from 0.
+const simulatedCPUs = 1
+
+// maxCPUs bounds the CPU numbers an affinity can name, one per mask bit.
+const maxCPUs = 64
+
+// parseAffinity reads the CPUs a process may run on, either as a hex
+// bitmask such as "0x5" or as a CPU list such as "0-3,6". The result is a
+// bitmask with bit n set for CPU n. An empty affinity means any CPU and
+// parses to 0.
+func parseAffinity(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		mask, err := strconv.ParseUint(hex, 16, 64)
+		if err != nil || mask == 0 {
+			return 0, fmt.Errorf("affinity %q is not a non-zero hex CPU mask", s)
+		}
+		return mask, nil
+	}
+	var mask uint64
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := parseCPU(from)
+		if err != nil {
+			return 0, fmt.Errorf("affinity %q: %v", s, err)
+		}
+		last := first
+		if isRange {
+			if last, err = parseCPU(to); err != nil {
+				return 0, fmt.Errorf("affinity %q: %v", s, err)
+			}
+			if last < first {
+				return 0, fmt.Errorf("affinity %q: range %s is reversed", s, part)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			mask |= 1 << cpu
+		}
+	}
+	return mask, nil
+}
+
+func parseCPU(s string) (int, error) {
+	cpu, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || cpu < 0 || cpu >= maxCPUs {
+		return 0, fmt.Errorf("CPU %q is not a number from 0 to %d", s, maxCPUs-1)
+	}
+	return cpu, nil
+}
+
+// formatAffinity writes mask as the CPU list parseAffinity reads back,
+// collapsing runs into ranges.
+func formatAffinity(mask uint64) string {
+	var parts []string
+	for mask != 0 {
+		first := bits.TrailingZeros64(mask)
+		last := first + bits.TrailingZeros64(^(mask >> first)) - 1
+		if last == first {
+			parts = append(parts, strconv.Itoa(first))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", first, last))
+		}
+		mask &^= (1<<(last-first+1) - 1) << first
+	}
+	return strings.Join(parts, ",")
+}
+
+// checkAffinity rejects an affinity that allows none of the simulated
+// CPUs, as the process could never run.
+func checkAffinity(mask uint64) error {
+	if mask != 0 && mask&(1<<simulatedCPUs-1) == 0 {
+		return fmt.Errorf("affinity %s allows none of the %d simulated CPU(s)", formatAffinity(mask), simulatedCPUs)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func Test_parseAffinity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    uint64
+		list    string
+		wantErr bool
+	}{
+		{name: "any", input: "", want: 0, list: ""},
+		{name: "single CPU", input: "0", want: 0b1, list: "0"},
+		{name: "list and range", input: "0-3, 6", want: 0b1001111, list: "0-3,6"},
+		{name: "hex mask", input: "0x5", want: 0b101, list: "0,2"},
+		{name: "every CPU", input: "0-63", want: ^uint64(0), list: "0-63"},
+		{name: "zero mask", input: "0x0", wantErr: true},
+		{name: "reversed range", input: "3-1", wantErr: true},
+		{name: "CPU out of range", input: "64", wantErr: true},
+		{name: "not a number", input: "all", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseAffinity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAffinity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAffinity() = %#b, want %#b", got, tt.want)
+			}
+			if list := formatAffinity(got); !tt.wantErr && list != tt.list {
+				t.Errorf("formatAffinity() = %q, want %q", list, tt.list)
+			}
+		})
+	}
+}
+
+func Test_checkAffinity(t *testing.T) {
+	t.Parallel()
+	if err := checkAffinity(0); err != nil {
+		t.Errorf("checkAffinity(any) = %v, want nil", err)
+	}
+	if err := checkAffinity(0b11); err != nil {
+		t.Errorf("checkAffinity(0-1) = %v, want nil", err)
+	}
+	if err := checkAffinity(0b10); err == nil {
+		t.Error("checkAffinity(1) = nil, want an error for a CPU that is not simulated")
+	}
+}
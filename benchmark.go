@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// minBenchmarkTime is how long each algorithm is rerun for, so that the
+// reported simulation time of small workloads is not just timer noise.
+const minBenchmarkTime = 50 * time.Millisecond
+
+type benchmarkRow struct {
+	scheduler Scheduler
+	runQueue  string
+	result    Result
+	runtime   time.Duration
+}
+
+// benchmark runs every selected algorithm on one workload, timing the
+// simulation, and prints them ranked by average turnaround then wait.
+// Algorithms backed by a run queue are timed once per implementation,
+// which must all produce the same result. It accepts the same flags as
+// a regular run.
+func benchmark(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"benchmark"}, args...)...)
+	if err != nil {
+		return err
+	}
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+	processes, err := loadWorkload(f.Name(), f, cfg.loadOptions())
+	if err != nil {
+		return err
+	}
+
+	rows := make([]benchmarkRow, 0, len(cfg.Algorithms))
+	for _, name := range cfg.Algorithms {
+		s, _ := lookupScheduler(name)
+		queues := []string{""}
+		if slices.Contains(s.Params, "run_queue") {
+			queues = runQueueNames
+		}
+		for _, queue := range queues {
+			c := cfg
+			if queue != "" {
+				c.RunQueue = queue
+			}
+			var (
+				r     Result
+				runs  int
+				start = time.Now()
+			)
+			for runs == 0 || time.Since(start) < minBenchmarkTime {
+				r = s.Schedule(processes, c)
+				runs++
+			}
+			if queue != "" && queue != queues[0] {
+				if first := rows[len(rows)-1]; !reflect.DeepEqual(r, first.result) {
+					return fmt.Errorf("%w: %s results differ between the %s and %s run queues", ErrAssertion, s.Name, queues[0], queue)
+				}
+			}
+			rows = append(rows, benchmarkRow{
+				scheduler: s,
+				runQueue:  queue,
+				result:    r,
+				runtime:   time.Since(start) / time.Duration(runs),
+			})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].result.AveTurnaround != rows[j].result.AveTurnaround {
+			return rows[i].result.AveTurnaround < rows[j].result.AveTurnaround
+		}
+		return rows[i].result.AveWait < rows[j].result.AveWait
+	})
+
+	nf := cfg.numberFormat()
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{
+		nf.header("Rank"), nf.header("Algorithm"), nf.header("Run queue"), nf.header("Avg wait"),
+		nf.header("Avg turnaround"), nf.header("Throughput"), nf.header("Sim time"),
+	})
+	// Localized numbers are not recognized as numbers by tablewriter.
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	for i, row := range rows {
+		queue := row.runQueue
+		if queue == "" {
+			queue = "-"
+		}
+		table.Append([]string{
+			nf.int(int64(i + 1)),
+			row.scheduler.Name,
+			queue,
+			nf.duration(row.result.AveWait),
+			nf.duration(row.result.AveTurnaround),
+			nf.rate(row.result.AveThroughput),
+			row.runtime.String(),
+		})
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Burst is one stretch of a process's life, either computing on the CPU
+// or blocked on I/O.
+type Burst struct {
+	IO       bool
+	Duration int64
+}
+
+func (b Burst) String() string {
+	if b.IO {
+		return fmt.Sprintf("io:%d", b.Duration)
+	}
+	return fmt.Sprintf("cpu:%d", b.Duration)
+}
+
+// parseBursts reads a burst sequence such as "cpu:5,io:3,cpu:4", whose
+// lengths are read as parseTicks reads them, so "cpu:150ms" and
+// "cpu:2.5" are allowed where opts allows. Bursts must alternate between
+// CPU and I/O, start and end on the CPU and last at least one tick. An
+// empty sequence means the process has none.
+func parseBursts(s string, opts loadOptions) ([]Burst, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	bursts := make([]Burst, len(parts))
+	for i, part := range parts {
+		kind, duration, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("burst %q is not kind:duration, e.g. cpu:5", part)
+		}
+		switch strings.ToLower(strings.TrimSpace(kind)) {
+		case "cpu":
+		case "io":
+			bursts[i].IO = true
+		default:
+			return nil, fmt.Errorf("burst %q has unknown kind %q, want cpu or io", part, kind)
+		}
+		d, err := parseTicks(duration, opts)
+		if err != nil {
+			return nil, fmt.Errorf("burst %q: %v", part, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("burst %q must last a positive whole number of ticks", part)
+		}
+		bursts[i].Duration = d
+		if i > 0 && bursts[i].IO == bursts[i-1].IO {
+			return nil, fmt.Errorf("bursts must alternate between cpu and io, got %s after %s", bursts[i], bursts[i-1])
+		}
+	}
+	if bursts[0].IO || bursts[len(bursts)-1].IO {
+		return nil, fmt.Errorf("bursts must start and end on the cpu, got %q", s)
+	}
+	return bursts, nil
+}
+
+// formatBursts writes bursts in the form parseBursts reads.
+func formatBursts(bursts []Burst) string {
+	parts := make([]string, len(bursts))
+	for i, b := range bursts {
+		parts[i] = b.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// withBursts gives p the burst sequence bursts, whose total CPU time
+// becomes its burst duration. A burst duration given as well must agree.
+func withBursts(p Process, bursts []Burst) (Process, error) {
+	if bursts == nil {
+		return p, nil
+	}
+	var cpu int64
+	for _, b := range bursts {
+		if !b.IO {
+			cpu += b.Duration
+		}
+	}
+	if p.BurstDuration != 0 && p.BurstDuration != cpu {
+		return p, fmt.Errorf("burst duration %d does not match the %d CPU ticks in bursts", p.BurstDuration, cpu)
+	}
+	p.BurstDuration = cpu
+	p.Bursts = bursts
+	return p, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseBursts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []Burst
+		wantErr bool
+	}{
+		{name: "empty", input: " ", want: nil},
+		{name: "single cpu burst", input: "cpu:5", want: []Burst{{Duration: 5}}},
+		{
+			name:  "alternating",
+			input: "cpu:5, IO:3 ,cpu:4",
+			want:  []Burst{{Duration: 5}, {IO: true, Duration: 3}, {Duration: 4}},
+		},
+		{name: "missing duration", input: "cpu", wantErr: true},
+		{name: "unknown kind", input: "gpu:5", wantErr: true},
+		{name: "zero duration", input: "cpu:0", wantErr: true},
+		{name: "two cpu bursts in a row", input: "cpu:1,cpu:2", wantErr: true},
+		{name: "starts on io", input: "io:1,cpu:2", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseBursts(tt.input, loadOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBursts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBursts() = %v, want %v", got, tt.want)
+			}
+			if again, _ := parseBursts(formatBursts(got), loadOptions{}); !reflect.DeepEqual(again, got) {
+				t.Errorf("parseBursts(formatBursts()) = %v, want %v", again, got)
+			}
+		})
+	}
+}
+
+func Test_withBursts(t *testing.T) {
+	t.Parallel()
+	bursts := []Burst{{Duration: 2}, {IO: true, Duration: 7}, {Duration: 3}}
+
+	p, err := withBursts(Process{ProcessID: 1}, bursts)
+	if err != nil || p.BurstDuration != 5 {
+		t.Errorf("withBursts() = %v, %v, want a burst duration of 5", p, err)
+	}
+	if _, err := withBursts(Process{ProcessID: 1, BurstDuration: 4}, bursts); err == nil {
+		t.Error("withBursts() with a disagreeing burst duration succeeded")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// canonicalVersion heads --canonical output. It changes only when the
+// format does, so graders can tell which layout they are reading.
+const canonicalVersion = "scheduler-canonical 1"
+
+// outputCanonical writes one algorithm's result in the --canonical
+// format: one record per line, a keyword followed by space-separated
+// key=value fields, with times in raw ticks and averages to two decimals.
+// It ignores every cosmetic option, so it only changes when the schedule
+// does.
+//
+//	algorithm fcfs
+//	slice pid=1 start=0 stop=5
+//	process pid=1 arrival=0 burst=5 priority=2 wait=0 turnaround=5 response=0 exit=5
+//	average wait=3.33 turnaround=10.00 response=3.33 throughput=0.15
+func outputCanonical(w io.Writer, name string, r Result) {
+	_, _ = fmt.Fprintf(w, "algorithm %s\n", name)
+	for _, s := range r.Gantt {
+		if s.Idle {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "slice pid=%d start=%d stop=%d\n", s.PID, s.Start, s.Stop)
+	}
+	for _, row := range r.Schedule {
+		_, _ = fmt.Fprintf(w, "process pid=%d arrival=%d burst=%d priority=%d wait=%d turnaround=%d response=%d exit=%d\n",
+			row.ProcessID, row.Arrival, row.Burst, row.Priority, row.Wait, row.Turnaround, row.Response, row.Exit)
+	}
+	_, _ = fmt.Fprintf(w, "average wait=%s turnaround=%s response=%s throughput=%s\n",
+		canonicalFloat(r.AveWait), canonicalFloat(r.AveTurnaround), canonicalFloat(r.AveResponse), canonicalFloat(r.AveThroughput))
+}
+
+func canonicalFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputCanonical(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var w bytes.Buffer
+	outputCanonical(&w, "sjf", simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{}))
+
+	want := `algorithm sjf
+slice pid=1 start=0 stop=5
+slice pid=2 start=5 stop=6
+slice pid=3 start=6 stop=12
+slice pid=2 start=12 stop=20
+process pid=1 arrival=0 burst=5 priority=2 wait=0 turnaround=5 response=0 exit=5
+process pid=2 arrival=3 burst=9 priority=1 wait=8 turnaround=17 response=2 exit=20
+process pid=3 arrival=6 burst=6 priority=3 wait=0 turnaround=6 response=0 exit=12
+average wait=2.67 turnaround=9.33 response=0.67 throughput=0.15
+`
+	if got := w.String(); got != want {
+		t.Errorf("outputCanonical() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// chaptersIndex is the table of contents --format chapters writes.
+const chaptersIndex = "index.txt"
+
+// chapter is one file of a report split by outputChapters.
+type chapter struct {
+	file, title string
+}
+
+// outputChapters splits a run's text report into files in cfg.OutputDir,
+// so giant reports stay navigable: a chapter per algorithm, named after
+// it, and index.txt listing them, then the comparison table when more than
+// one algorithm ran. With cfg.ChapterRows set, an algorithm's schedule
+// table is split across parts of that many rows, its Gantt chart and
+// breakdowns in the first. w lists the files written.
+func outputChapters(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	dir := cfg.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%v: error creating output directory", err)
+	}
+	opts := cfg.outputOptions()
+	opts.color = false
+
+	var chapters []chapter
+	for _, row := range summary {
+		written, err := writeChapter(w, dir, row, opts, cfg.ChapterRows)
+		if err != nil {
+			return err
+		}
+		chapters = append(chapters, written...)
+	}
+
+	return writeChapterFile(w, filepath.Join(dir, chaptersIndex), func(f io.Writer) {
+		_, _ = fmt.Fprintln(f, "Chapters")
+		width := 0
+		for _, c := range chapters {
+			width = max(width, len(c.file))
+		}
+		for _, c := range chapters {
+			_, _ = fmt.Fprintf(f, "  %-*s  %s\n", width, c.file, c.title)
+		}
+		if len(summary) > 1 {
+			_, _ = fmt.Fprintln(f)
+			outputComparison(f, summary, opts)
+		}
+	})
+}
+
+// writeChapter writes one algorithm's report, in parts of at most rows
+// schedule table rows when rows is set, and returns the chapters written.
+func writeChapter(w io.Writer, dir string, row summaryRow, opts outputOptions, rows int) ([]chapter, error) {
+	r, title := row.Result, row.Scheduler.Title
+	if opts.columns == nil {
+		// Parts must share the columns the whole schedule calls for.
+		opts.columns = defaultColumnsFor(r.Schedule)
+	}
+	schedule := opts.sortBy.sort(r.Schedule)
+	if opts.maxRows > 0 && len(schedule) > opts.maxRows {
+		schedule = schedule[:opts.maxRows]
+	}
+	if rows <= 0 || len(schedule) <= rows {
+		c := chapter{file: row.Scheduler.Name + ".txt", title: title}
+		err := writeChapterFile(w, filepath.Join(dir, c.file), func(f io.Writer) {
+			outputResult(f, title, r, opts)
+		})
+		return []chapter{c}, err
+	}
+
+	parts := (len(schedule) + rows - 1) / rows
+	chapters := make([]chapter, 0, parts)
+	for part := 0; part < parts; part++ {
+		first, last := part*rows, min((part+1)*rows, len(schedule))
+		c := chapter{
+			file:  fmt.Sprintf("%s-%d.txt", row.Scheduler.Name, part+1),
+			title: fmt.Sprintf("%s, rows %d–%d of %d", title, first+1, last, len(schedule)),
+		}
+		err := writeChapterFile(w, filepath.Join(dir, c.file), func(f io.Writer) {
+			partOpts := opts
+			partOpts.tableRows = schedule[first:last]
+			if part == 0 {
+				outputResult(f, c.title, r, partOpts)
+				return
+			}
+			outputTitle(f, c.title)
+			outputSchedule(f, r, partOpts)
+		})
+		if err != nil {
+			return nil, err
+		}
+		chapters = append(chapters, c)
+	}
+	return chapters, nil
+}
+
+// writeChapterFile creates path, fills it with write and notes it on w.
+func writeChapterFile(w io.Writer, path string, write func(f io.Writer)) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating %s", err, path)
+	}
+	write(f)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%v: error writing %s", err, path)
+	}
+	_, _ = fmt.Fprintf(w, "wrote %s\n", path)
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_outputChapters(t *testing.T) {
+	t.Parallel()
+	tableRow := regexp.MustCompile(`(?m)^\| +(\d+) \| +\d+ \| +\d+ \|`)
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 2},
+	}
+	tests := []struct {
+		name  string
+		rows  int
+		files []string
+		parts map[string][]string // file → the IDs its table lists
+	}{
+		{
+			name:  "a file per algorithm",
+			files: []string{"fcfs.txt", "rr.txt", chaptersIndex},
+		},
+		{
+			name:  "split tables",
+			rows:  2,
+			files: []string{"fcfs-1.txt", "fcfs-2.txt", "rr-1.txt", "rr-2.txt", chaptersIndex},
+			parts: map[string][]string{
+				"fcfs-1.txt": {"1", "2"},
+				"fcfs-2.txt": {"3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := defaultConfig()
+			cfg.Algorithms = []string{"fcfs", "rr"}
+			cfg.Format = "chapters"
+			cfg.OutputDir = t.TempDir()
+			cfg.ChapterRows = tt.rows
+			var w bytes.Buffer
+			if err := run(&w, cfg, processes); err != nil {
+				t.Fatal(err)
+			}
+			var want strings.Builder
+			for _, file := range tt.files {
+				want.WriteString("wrote " + filepath.Join(cfg.OutputDir, file) + "\n")
+			}
+			if got := w.String(); got != want.String() {
+				t.Errorf("run() = %q, want %q", got, want.String())
+			}
+
+			index, err := os.ReadFile(filepath.Join(cfg.OutputDir, chaptersIndex))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range tt.files[:len(tt.files)-1] {
+				if !strings.Contains(string(index), "  "+file+"  ") {
+					t.Errorf("index does not list %s:\n%s", file, index)
+				}
+			}
+			if !strings.Contains(string(index), "Comparison") {
+				t.Errorf("index has no comparison table:\n%s", index)
+			}
+			for file, pids := range tt.parts {
+				part, err := os.ReadFile(filepath.Join(cfg.OutputDir, file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var got []string
+				for _, m := range tableRow.FindAllStringSubmatch(string(part), -1) {
+					got = append(got, m[1])
+				}
+				if !reflect.DeepEqual(got, pids) {
+					t.Errorf("%s lists %v, want %v:\n%s", file, got, pids, part)
+				}
+				if strings.Contains(string(part), " more") {
+					t.Errorf("%s notes rows that other parts list:\n%s", file, part)
+				}
+			}
+		})
+	}
+}
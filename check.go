@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseSlices reads a proposed Gantt chart: a JSON array of
+// {"pid", "start", "stop"} objects if name ends in .json, where those
+// marked "idle", as --format json writes them, are skipped, and otherwise
+// CSV rows of pid,start,stop, optionally under a header row with those
+// names in any order.
+func parseSlices(name string, r io.Reader) ([]TimeSlice, error) {
+	if strings.ToLower(filepath.Ext(name)) == ".json" {
+		var raw []struct {
+			PID   *int64 `json:"pid"`
+			Start *int64 `json:"start"`
+			Stop  *int64 `json:"stop"`
+			Idle  bool   `json:"idle"`
+		}
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		gantt := make([]TimeSlice, 0, len(raw))
+		for i, s := range raw {
+			if s.Idle {
+				continue
+			}
+			if s.PID == nil || s.Start == nil || s.Stop == nil {
+				return nil, fmt.Errorf("slice %d: want pid, start and stop", i+1)
+			}
+			gantt = append(gantt, TimeSlice{PID: *s.PID, Start: *s.Start, Stop: *s.Stop})
+		}
+		return gantt, nil
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	order := [3]int{0, 1, 2}
+	if len(rows) > 0 {
+		if _, err := strconv.ParseInt(strings.TrimSpace(rows[0][0]), 10, 64); err != nil {
+			for i, cell := range rows[0] {
+				switch strings.ToLower(strings.TrimSpace(cell)) {
+				case "pid":
+					order[0] = i
+				case "start":
+					order[1] = i
+				case "stop":
+					order[2] = i
+				default:
+					return nil, fmt.Errorf("line 1: unknown column %q, want pid, start and stop", cell)
+				}
+			}
+			rows = rows[1:]
+		}
+	}
+	gantt := make([]TimeSlice, len(rows))
+	for i, row := range rows {
+		var v [3]int64
+		for j, col := range order {
+			if v[j], err = strconv.ParseInt(strings.TrimSpace(row[col]), 10, 64); err != nil {
+				return nil, fmt.Errorf("row %d: %q is not an integer", i+1, row[col])
+			}
+		}
+		gantt[i] = TimeSlice{PID: v[0], Start: v[1], Stop: v[2]}
+	}
+	return gantt, nil
+}
+
+// scheduleViolations lists every way gantt fails to be a feasible
+// schedule of processes on one CPU: slices that are empty, overlap or run
+// unknown processes, processes that run before they arrive, before their
+// dependencies complete or while blocked on I/O, and processes whose
+// total service differs from their burst. Slices are taken in order of
+// their start.
+func scheduleViolations(processes []Process, gantt []TimeSlice) []string {
+	byPID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byPID[p.ProcessID] = p
+	}
+	sorted := append([]TimeSlice(nil), gantt...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	type progress struct {
+		first   int64 // start of the first slice
+		last    int64 // stop of the last slice
+		ran     int64
+		burst   int   // index of the CPU burst being served
+		used    int64 // time served of that burst
+		readyAt int64 // end of the last I/O burst
+	}
+	var (
+		violations []string
+		served     = make(map[int64]*progress, len(processes))
+		prev       *TimeSlice
+	)
+	for i := range sorted {
+		s := sorted[i]
+		p, ok := byPID[s.PID]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("slice %d..%d runs unknown process %d", s.Start, s.Stop, s.PID))
+			continue
+		case s.Stop <= s.Start:
+			violations = append(violations, fmt.Sprintf("slice %d..%d of process %d is empty or reversed", s.Start, s.Stop, s.PID))
+			continue
+		}
+		if prev != nil && s.Start < prev.Stop {
+			violations = append(violations, fmt.Sprintf("slice %d..%d of process %d overlaps slice %d..%d of process %d",
+				s.Start, s.Stop, s.PID, prev.Start, prev.Stop, prev.PID))
+		}
+		if prev == nil || s.Stop > prev.Stop {
+			prev = &sorted[i]
+		}
+		if s.Start < p.ArrivalTime {
+			violations = append(violations, fmt.Sprintf("process %d runs at %d, before it arrives at %d", s.PID, s.Start, p.ArrivalTime))
+		}
+		pr := served[s.PID]
+		if pr == nil {
+			pr = &progress{first: s.Start}
+			served[s.PID] = pr
+		}
+		pr.ran += s.Stop - s.Start
+		pr.last = max(pr.last, s.Stop)
+
+		// Walk the slice through the process's CPU bursts: each one
+		// finished sends it off to I/O, and it must not run again until
+		// that is over.
+		for at := s.Start; at < s.Stop && pr.burst < len(p.Bursts); {
+			if at < pr.readyAt {
+				violations = append(violations, fmt.Sprintf("process %d runs at %d, during its I/O until %d", s.PID, at, pr.readyAt))
+				break
+			}
+			run := min(p.Bursts[pr.burst].Duration-pr.used, s.Stop-at)
+			pr.used += run
+			at += run
+			if pr.used == p.Bursts[pr.burst].Duration {
+				pr.used = 0
+				if pr.burst+1 < len(p.Bursts) {
+					pr.readyAt = at + p.Bursts[pr.burst+1].Duration
+				}
+				pr.burst += 2
+			}
+		}
+	}
+
+	for _, p := range processes {
+		var ran int64
+		if pr := served[p.ProcessID]; pr != nil {
+			ran = pr.ran
+		}
+		if ran != p.BurstDuration {
+			violations = append(violations, fmt.Sprintf("process %d runs for %d, want its burst of %d", p.ProcessID, ran, p.BurstDuration))
+		}
+	}
+	for _, p := range processes {
+		pr := served[p.ProcessID]
+		for _, dep := range p.DependsOn {
+			if d := served[dep]; pr != nil && d != nil && pr.first < d.last {
+				violations = append(violations, fmt.Sprintf("process %d runs at %d, before process %d it depends on completes at %d",
+					p.ProcessID, pr.first, dep, d.last))
+			}
+		}
+	}
+	return violations
+}
+
+// proposedResult assembles a feasible proposed schedule into a Result, as
+// if it had been simulated.
+func proposedResult(processes []Process, gantt []TimeSlice) Result {
+	sorted := append([]TimeSlice(nil), gantt...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	r := Result{Gantt: sorted}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].PID != sorted[i-1].PID {
+			r.ContextSwitches++
+		}
+	}
+	tasks := newTasks(processes, false)
+	byPID := make(map[int64]*task, len(tasks))
+	for _, t := range tasks {
+		t.firstRun, t.completion = t.ArrivalTime, t.ArrivalTime
+		byPID[t.ProcessID] = t
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		byPID[sorted[i].PID].firstRun = sorted[i].Start
+	}
+	for _, s := range sorted {
+		byPID[s.PID].completion = s.Stop
+	}
+	finishResult(&r, tasks)
+	r.Gantt = fillIdle(r.Gantt)
+	return r
+}
+
+// check verifies a proposed schedule for a workload, such as one worked
+// out by hand, and prints every violation. A feasible schedule is shown
+// as if simulated, with the same display flags as a regular run.
+func check(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"check"}, args...)...)
+	if err != nil {
+		return err
+	}
+	if len(args) != 3 {
+		return fmt.Errorf("%w: check takes a workload file and a schedule file", ErrInvalidArgs)
+	}
+	wf, closeWorkload, err := openProcessingFile(args[:2]...)
+	if err != nil {
+		return err
+	}
+	defer closeWorkload()
+	processes, err := loadWorkload(wf.Name(), wf, cfg.loadOptions())
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[2])
+	if err != nil {
+		return fmt.Errorf("%w: %v: error opening schedule file", ErrInvalidArgs, err)
+	}
+	defer f.Close()
+	gantt, err := parseSlices(f.Name(), f)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidWorkload, f.Name(), err)
+	}
+
+	if violations := scheduleViolations(processes, gantt); len(violations) > 0 {
+		for _, v := range violations {
+			_, _ = fmt.Fprintf(w, "%s: %s\n", f.Name(), v)
+		}
+		return fmt.Errorf("%w: %s: %d violation(s)", ErrAssertion, f.Name(), len(violations))
+	}
+	outputResult(w, "Proposed schedule", proposedResult(processes, gantt), cfg.outputOptions())
+	return nil
+}
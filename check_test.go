@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseSlices(t *testing.T) {
+	t.Parallel()
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}}
+	tests := []struct {
+		name    string
+		file    string
+		input   string
+		want    []TimeSlice
+		wantErr bool
+	}{
+		{name: "csv", file: "s.csv", input: "1,0,5\n2,5,14\n", want: want},
+		{name: "csv with header", file: "s.csv", input: "start,stop,pid\n0,5,1\n5,14,2\n", want: want},
+		{name: "json", file: "s.json", input: `[{"pid": 1, "start": 0, "stop": 5}, {"pid": 2, "start": 5, "stop": 14}]`, want: want},
+		{name: "json missing stop", file: "s.json", input: `[{"pid": 1, "start": 0}]`, wantErr: true},
+		{name: "unknown csv column", file: "s.csv", input: "pid,start,end\n1,0,5\n", wantErr: true},
+		{name: "non-integer", file: "s.csv", input: "1,0,x\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSlices(tt.file, strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSlices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSlices() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scheduleViolations(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 4},
+	}
+	cycles := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Bursts: []Burst{{Duration: 2}, {IO: true, Duration: 3}, {Duration: 3}}},
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		gantt     []TimeSlice
+		want      []string
+	}{
+		{
+			name:      "feasible, in any order",
+			processes: processes,
+			gantt:     []TimeSlice{{PID: 2, Start: 5, Stop: 9}, {PID: 1, Start: 0, Stop: 5}},
+		},
+		{
+			name:      "every violation is reported",
+			processes: processes,
+			gantt:     []TimeSlice{{PID: 2, Start: 0, Stop: 4}, {PID: 1, Start: 3, Stop: 6}, {PID: 3, Start: 6, Stop: 7}},
+			want: []string{
+				"process 2 runs at 0, before it arrives at 3",
+				"slice 3..6 of process 1 overlaps slice 0..4 of process 2",
+				"slice 6..7 runs unknown process 3",
+				"process 1 runs for 3, want its burst of 5",
+			},
+		},
+		{
+			name:      "I/O is waited out",
+			processes: cycles,
+			gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 5, Stop: 8}},
+		},
+		{
+			name:      "running during I/O",
+			processes: cycles,
+			gantt:     []TimeSlice{{PID: 1, Start: 0, Stop: 5}},
+			want:      []string{"process 1 runs at 2, during its I/O until 5"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := scheduleViolations(tt.processes, tt.gantt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scheduleViolations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_check(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeSchedule := func(name, content string) string {
+		p := path.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	// example_processes.csv run first come, first served.
+	fcfs := writeSchedule("fcfs.csv", "pid,start,stop\n1,0,5\n2,5,14\n3,14,20\n")
+	late := writeSchedule("late.csv", "1,0,5\n3,5,11\n2,11,19\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "feasible", args: []string{"example_processes.csv", fcfs}, want: "Proposed schedule"},
+		{name: "infeasible", args: []string{"example_processes.csv", late}, want: "process 3 runs at 5, before it arrives at 6", wantErr: ErrAssertion},
+		{name: "missing schedule", args: []string{"example_processes.csv"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := check(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("check() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("check() = %v, want it to contain %q", w.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// pidColor is one entry of the process palette, given both as an xterm
+// 256-color index for terminals and as hex RGB for graphical renderers.
+type pidColor struct {
+	ANSI int
+	Hex  string
+}
+
+// pidPalette is shared by every renderer so a process keeps the same
+// color across output formats. Red is left out for highlighting problems.
+var pidPalette = []pidColor{
+	{ANSI: 31, Hex: "#1f77b4"},
+	{ANSI: 208, Hex: "#ff7f0e"},
+	{ANSI: 34, Hex: "#2ca02c"},
+	{ANSI: 97, Hex: "#9467bd"},
+	{ANSI: 95, Hex: "#8c564b"},
+	{ANSI: 175, Hex: "#e377c2"},
+	{ANSI: 244, Hex: "#7f7f7f"},
+	{ANSI: 142, Hex: "#bcbd22"},
+	{ANSI: 38, Hex: "#17becf"},
+}
+
+// idleColor paints the time the CPU spends idle in the Gantt chart.
+var idleColor = pidColor{ANSI: 160, Hex: "#d62728"}
+
+// Escape codes highlighting the best and worst values of a table.
+const (
+	ansiBest  = "\x1b[1;32m"
+	ansiWorst = "\x1b[1;31m"
+	ansiReset = "\x1b[0m"
+)
+
+// colorByDefault reports whether output to f is colored when neither
+// --color nor --no-color is given: only on a terminal, and not when the
+// NO_COLOR environment variable is set (see no-color.org) or TERM is dumb.
+func colorByDefault(f *os.File, getenv func(string) string) bool {
+	if getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorForPID returns the stable palette color of a process.
+func colorForPID(pid int64) pidColor {
+	i := pid % int64(len(pidPalette))
+	if i < 0 {
+		i += int64(len(pidPalette))
+	}
+	return pidPalette[i]
+}
+
+// ansiBackground wraps s in escape codes painting it on c with black text.
+func ansiBackground(c pidColor, s string) string {
+	return fmt.Sprintf("\x1b[48;5;%dm\x1b[38;5;16m%s"+ansiReset, c.ANSI, s)
+}
+
+// outputLegend prints a swatch and the PID of every process in the Gantt.
+func outputLegend(w io.Writer, gantt []TimeSlice) {
+	seen := make(map[int64]bool)
+	pids := make([]int64, 0)
+	for _, ts := range gantt {
+		if !ts.Idle && !seen[ts.PID] {
+			seen[ts.PID] = true
+			pids = append(pids, ts.PID)
+		}
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+
+	_, _ = fmt.Fprint(w, "Legend:")
+	for _, pid := range pids {
+		_, _ = fmt.Fprint(w, " ", ansiBackground(colorForPID(pid), "  "), " ", pid)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_colorForPID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		pid  int64
+		want pidColor
+	}{
+		{name: "first", pid: 0, want: pidPalette[0]},
+		{name: "wraps around", pid: int64(len(pidPalette)) + 2, want: pidPalette[2]},
+		{name: "negative", pid: -1, want: pidPalette[len(pidPalette)-1]},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := colorForPID(tt.pid); got != tt.want {
+				t.Errorf("colorForPID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputLegend(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputLegend(&w, []TimeSlice{{PID: 3}, {PID: 1}, {PID: 3}})
+
+	got := w.String()
+	if strings.Count(got, "\x1b[48;5;") != 2 {
+		t.Errorf("outputLegend() = %q, want one swatch per PID", got)
+	}
+	if strings.Index(got, " 1") > strings.Index(got, " 3") {
+		t.Errorf("outputLegend() = %q, want PIDs in ascending order", got)
+	}
+}
+
+func Test_colorByDefault(t *testing.T) {
+	t.Parallel()
+	// The null device is a character device, as a terminal is.
+	device, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = device.Close() })
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = file.Close() })
+
+	tests := []struct {
+		name string
+		f    *os.File
+		env  map[string]string
+		want bool
+	}{
+		{name: "terminal", f: device, want: true},
+		{name: "NO_COLOR", f: device, env: map[string]string{"NO_COLOR": "1"}, want: false},
+		{name: "dumb terminal", f: device, env: map[string]string{"TERM": "dumb"}, want: false},
+		{name: "redirected", f: file, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			if got := colorByDefault(tt.f, getenv); got != tt.want {
+				t.Errorf("colorByDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// scheduleColumn is one selectable column of the schedule table.
+type scheduleColumn struct {
+	Name   string
+	Header string
+	Value  func(row ScheduleRow) int64
+	// Time marks values in ticks, which are rendered in the time unit.
+	Time bool
+	// At marks time values that are points in time rather than
+	// durations, rendered as timestamps when the run has an epoch.
+	At bool
+	// Plain values are identifiers, printed without locale grouping.
+	Plain bool
+	// Average, when set, is shown in the footer below the column.
+	Average func(r Result) float64
+	// Default columns are shown when --columns is not given.
+	Default bool
+	// Present, when set, reports whether the row has a value at all;
+	// rows without one show "-".
+	Present func(row ScheduleRow) bool
+	// Text, when set, gives the value of a label column in place of
+	// Value.
+	Text func(row ScheduleRow) string
+}
+
+func hasDeadline(row ScheduleRow) bool { return row.Deadline > 0 }
+
+func hasName(row ScheduleRow) bool { return row.Name != "" }
+
+func hasOwner(row ScheduleRow) bool { return row.Owner != "" }
+
+func hasIO(row ScheduleRow) bool { return row.IO > 0 }
+
+var scheduleColumns = []scheduleColumn{
+	{Name: "id", Header: "ID", Value: func(row ScheduleRow) int64 { return row.ProcessID }, Plain: true, Default: true},
+	{Name: "name", Header: "Name", Text: func(row ScheduleRow) string { return row.Name }, Present: hasName},
+	{Name: "owner", Header: "Owner", Text: func(row ScheduleRow) string { return row.Owner }, Present: hasOwner},
+	{Name: "priority", Header: "Priority", Value: func(row ScheduleRow) int64 { return row.Priority }, Default: true},
+	{Name: "burst", Header: "Burst", Value: func(row ScheduleRow) int64 { return row.Burst }, Time: true, Default: true},
+	{Name: "io", Header: "I/O", Value: func(row ScheduleRow) int64 { return row.IO }, Time: true},
+	{Name: "arrival", Header: "Arrival", Value: func(row ScheduleRow) int64 { return row.Arrival }, Time: true, At: true, Default: true},
+	{
+		Name: "wait", Header: "Wait", Value: func(row ScheduleRow) int64 { return row.Wait }, Time: true, Default: true,
+		Average: func(r Result) float64 { return r.AveWait },
+	},
+	{
+		Name: "turnaround", Header: "Turnaround", Value: func(row ScheduleRow) int64 { return row.Turnaround }, Time: true, Default: true,
+		Average: func(r Result) float64 { return r.AveTurnaround },
+	},
+	{
+		Name: "response", Header: "Response", Value: func(row ScheduleRow) int64 { return row.Response }, Time: true,
+		Average: func(r Result) float64 { return r.AveResponse },
+	},
+	{Name: "exit", Header: "Exit", Value: func(row ScheduleRow) int64 { return row.Exit }, Time: true, At: true, Default: true},
+	{Name: "deadline", Header: "Deadline", Value: func(row ScheduleRow) int64 { return row.Deadline }, Time: true, At: true, Present: hasDeadline},
+	{Name: "missed", Header: "Missed by", Value: func(row ScheduleRow) int64 { return row.MissedBy }, Time: true, Present: hasDeadline},
+}
+
+func defaultScheduleColumns() []scheduleColumn {
+	var columns []scheduleColumn
+	for _, c := range scheduleColumns {
+		if c.Default {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+// defaultColumnsFor returns the default columns, with "name" and then
+// "owner" after the ID when any row has one, "io" after the burst when any
+// did I/O, and "missed" last when any has a deadline, so every algorithm
+// reports missed deadlines without being asked.
+func defaultColumnsFor(rows []ScheduleRow) []scheduleColumn {
+	var names, owners, io, deadlines bool
+	for _, row := range rows {
+		names = names || hasName(row)
+		owners = owners || hasOwner(row)
+		io = io || hasIO(row)
+		deadlines = deadlines || hasDeadline(row)
+	}
+	columns := defaultScheduleColumns()
+	if names {
+		columns = insertColumn(columns, "id", "name")
+	}
+	if owners {
+		after := "id"
+		if names {
+			after = "name"
+		}
+		columns = insertColumn(columns, after, "owner")
+	}
+	if io {
+		columns = insertColumn(columns, "burst", "io")
+	}
+	if deadlines {
+		missed, _ := lookupScheduleColumn("missed")
+		columns = append(columns, missed)
+	}
+	return columns
+}
+
+// insertColumn inserts the named column after the column after.
+func insertColumn(columns []scheduleColumn, after, name string) []scheduleColumn {
+	c, _ := lookupScheduleColumn(name)
+	for i := range columns {
+		if columns[i].Name == after {
+			return append(columns[:i+1], append([]scheduleColumn{c}, columns[i+1:]...)...)
+		}
+	}
+	return append(columns, c)
+}
+
+func lookupScheduleColumn(name string) (scheduleColumn, bool) {
+	for _, c := range scheduleColumns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return scheduleColumn{}, false
+}
+
+func scheduleColumnNames() string {
+	names := make([]string, len(scheduleColumns))
+	for i, c := range scheduleColumns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// parseColumns parses a --columns list such as "id,wait,response". An
+// empty list returns nil, leaving the choice to defaultColumnsFor.
+func parseColumns(names []string) ([]scheduleColumn, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	columns := make([]scheduleColumn, 0, len(names))
+	for _, name := range names {
+		c, ok := lookupScheduleColumn(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, want one of %s", name, scheduleColumnNames())
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+func (c scheduleColumn) format(row ScheduleRow, nf numberFormat) string {
+	switch {
+	case c.Present != nil && !c.Present(row):
+		return "-"
+	case c.Text != nil:
+		return c.Text(row)
+	case c.At:
+		return nf.at(c.Value(row))
+	case c.Time:
+		return nf.time(c.Value(row))
+	case c.Plain:
+		return strconv.FormatInt(c.Value(row), 10)
+	}
+	return nf.int(c.Value(row))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseColumns(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "defaults left to the table", names: nil, want: nil},
+		{name: "selected in order", names: []string{"Response", " id"}, want: []string{"response", "id"}},
+		{name: "unknown", names: []string{"id", "weight"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			columns, err := parseColumns(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var got []string
+			for _, c := range columns {
+				got = append(got, c.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_defaultColumnsFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		rows []ScheduleRow
+		want []string
+	}{
+		{
+			name: "no deadlines",
+			rows: []ScheduleRow{{ProcessID: 1}},
+			want: []string{"id", "priority", "burst", "arrival", "wait", "turnaround", "exit"},
+		},
+		{
+			name: "some names",
+			rows: []ScheduleRow{{ProcessID: 1, Name: "editor"}, {ProcessID: 2}},
+			want: []string{"id", "name", "priority", "burst", "arrival", "wait", "turnaround", "exit"},
+		},
+		{
+			name: "names and owners",
+			rows: []ScheduleRow{{ProcessID: 1, Name: "editor"}, {ProcessID: 2, Owner: "alice"}},
+			want: []string{"id", "name", "owner", "priority", "burst", "arrival", "wait", "turnaround", "exit"},
+		},
+		{
+			name: "some deadlines",
+			rows: []ScheduleRow{{ProcessID: 1}, {ProcessID: 2, Deadline: 9}},
+			want: []string{"id", "priority", "burst", "arrival", "wait", "turnaround", "exit", "missed"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, c := range defaultColumnsFor(tt.rows) {
+				got = append(got, c.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultColumnsFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// command is a subcommand selected by the first CLI argument. Anything
+// that is not a command name runs the default simulation.
+type command struct {
+	Name        string
+	Description string
+	Run         func(w io.Writer, args ...string) error
+}
+
+var commands = []command{
+	{
+		Name:        "list-algorithms",
+		Description: "List the registered schedulers and their tunable parameters",
+		Run:         listAlgorithms,
+	},
+	{
+		Name:        "example",
+		Description: "Run a built-in textbook workload: convoy, mixed or realtime (list them without a name)",
+		Run:         example,
+	},
+	{
+		Name:        "validate",
+		Description: "Check a workload file for problems without simulating it",
+		Run:         validate,
+	},
+	{
+		Name:        "benchmark",
+		Description: "Run and time every algorithm on a workload, ranked by turnaround",
+		Run:         benchmark,
+	},
+	{
+		Name:        "generate",
+		Description: "Write a random workload CSV, reproducible with --seed",
+		Run:         generate,
+	},
+	{
+		Name:        "import",
+		Description: "Convert a scheduler trace from a real machine, e.g. perf sched timehist output or Perfetto JSON, into a workload CSV",
+		Run:         importTrace,
+	},
+	{
+		Name:        "snapshot",
+		Description: "Write the processes on this Linux machine, read from /proc, as a workload CSV",
+		Run:         snapshot,
+	},
+	{
+		Name:        "minimize",
+		Description: "Shrink a workload to the fewest processes that still trigger a failure",
+		Run:         minimize,
+	},
+	{
+		Name:        "equivalence",
+		Description: "Check that the event-driven and tick-based engines produce identical results",
+		Run:         equivalence,
+	},
+	{
+		Name:        "replay",
+		Description: "Render recorded schedules, e.g. --canonical output or an external scheduler's, as if simulated",
+		Run:         replay,
+	},
+	{
+		Name:        "run",
+		Description: "Run a scenario file bundling a workload, settings and expected results",
+		Run:         runScenario,
+	},
+	{
+		Name:        "check",
+		Description: "Check a proposed schedule, e.g. one worked out by hand, against a workload",
+		Run:         check,
+	},
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// listAlgorithms prints every registered scheduler with a one-line
+// description and the Config knobs it can be tuned with.
+func listAlgorithms(w io.Writer, args ...string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("%w: list-algorithms takes no arguments", ErrInvalidArgs)
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Name", "Description", "Parameters"})
+	table.SetAutoWrapText(false)
+	for _, s := range schedulers {
+		params := "-"
+		if len(s.Params) > 0 {
+			params = strings.Join(s.Params, ", ")
+		}
+		table.Append([]string{s.Name, s.Description, params})
+	}
+	table.Render()
+	return nil
+}
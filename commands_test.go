@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_listAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "unexpected argument",
+			args:    []string{"rr"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := listAlgorithms(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listAlgorithms() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for _, s := range schedulers {
+				if !strings.Contains(w.String(), s.Description) {
+					t.Errorf("listAlgorithms() = %v, missing %s", w.String(), s.Name)
+				}
+			}
+		})
+	}
+}
+
+func Test_benchmark(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		wantOrder []string
+		wantCell  string
+		wantErr   error
+	}{
+		{
+			name:      "ranked by turnaround",
+			args:      []string{"example_processes.csv"},
+			wantOrder: []string{"sjf", "fcfs", "rr", "priority"},
+		},
+		{
+			name:     "in the time unit",
+			args:     []string{"--algorithms", "fcfs", "--time-unit", "ms", "--time-scale", "10", "example_processes.csv"},
+			wantCell: "/ms ",
+		},
+		{
+			name:    "missing workload",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := benchmark(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("benchmark() error = %v, want %v", err, tt.wantErr)
+			}
+			last := -1
+			for _, name := range tt.wantOrder {
+				i := strings.Index(w.String(), "| "+name+" ")
+				if i < last {
+					t.Errorf("benchmark() = %v, want order %v", w.String(), tt.wantOrder)
+				}
+				last = i
+			}
+			if !strings.Contains(w.String(), tt.wantCell) {
+				t.Errorf("benchmark() = %v, want a cell containing %q", w.String(), tt.wantCell)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// decompressors map the extensions of compressed workloads onto readers
+// that undo the compression.
+var decompressors = map[string]func(r io.Reader) (io.ReadCloser, error){
+	".gz": func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+	".zst": func(r io.Reader) (io.ReadCloser, error) {
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	},
+}
+
+// decompress unwraps a workload compressed as its name's extension says,
+// so trace.csv.gz reads as trace.csv. It returns the name without the
+// compression extension, which then selects the workload format. Other
+// files are returned unchanged.
+func decompress(name string, r io.Reader) (string, io.ReadCloser, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+	open, ok := decompressors[ext]
+	if !ok {
+		return name, io.NopCloser(r), nil
+	}
+	rc, err := open(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s: %v", ErrInvalidWorkload, name, err)
+	}
+	return strings.TrimSuffix(name, name[len(name)-len(ext):]), rc, nil
+}
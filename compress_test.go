@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func Test_loadWorkload_compressed(t *testing.T) {
+	t.Parallel()
+	csv := "1,5,0,2\n2,9,3,1\n"
+	yml := "processes:\n  - {pid: 1, burst: 5, arrival: 0, priority: 2}\n  - {pid: 2, burst: 9, arrival: 3, priority: 1}\n"
+	gz := func(s string) []byte {
+		var b bytes.Buffer
+		zw := gzip.NewWriter(&b)
+		_, _ = io.WriteString(zw, s)
+		_ = zw.Close()
+		return b.Bytes()
+	}
+	zst := func(s string) []byte {
+		var b bytes.Buffer
+		zw, err := zstd.NewWriter(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.WriteString(zw, s)
+		_ = zw.Close()
+		return b.Bytes()
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, Priority: 1},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "gzip csv", file: "trace.csv.gz", data: gz(csv)},
+		{name: "zstd csv", file: "trace.csv.zst", data: zst(csv)},
+		{name: "gzip yaml", file: "trace.yaml.GZ", data: gz(yml)},
+		{name: "not actually gzip", file: "trace.csv.gz", data: []byte(csv), wantErr: ErrInvalidWorkload},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadWorkload(tt.file, bytes.NewReader(tt.data), loadOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadWorkload() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("loadWorkload() = %v, want %v", got, want)
+			}
+			if _, problems := parseWorkloadFile(tt.file, bytes.NewReader(tt.data), loadOptions{}); len(problems) != 0 {
+				t.Errorf("parseWorkloadFile() problems = %v", problems)
+			}
+		})
+	}
+}
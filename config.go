@@ -0,0 +1,489 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the simulation parameters shared by every run. It can be
+// described in a YAML, JSON or TOML file (--config), or in a scenario
+// workload itself, and overridden by flags.
+type Config struct {
+	Algorithms    []string `yaml:"algorithms" toml:"algorithms"`
+	Quantum       int64    `yaml:"quantum" toml:"quantum"`
+	SchedOverhead int64    `yaml:"sched_overhead" toml:"sched_overhead"`
+	Sweep         string   `yaml:"sweep" toml:"sweep"`
+	SweepFormat   string   `yaml:"sweep_format" toml:"sweep_format"`
+	Color         bool     `yaml:"color" toml:"color"`
+	Quiet         bool     `yaml:"quiet" toml:"quiet"`
+	Matrix        bool     `yaml:"matrix" toml:"matrix"`
+	Canonical     bool     `yaml:"canonical" toml:"canonical"`
+	Format        string   `yaml:"format" toml:"format"`
+	Template      string   `yaml:"template" toml:"template"`
+	OutputDir     string   `yaml:"output_dir" toml:"output_dir"`
+	Compare       bool     `yaml:"compare" toml:"compare"`
+	From          int64    `yaml:"from" toml:"from"`
+	To            int64    `yaml:"to" toml:"to"`
+	Step          bool     `yaml:"-" toml:"-"`
+	Explain       bool     `yaml:"explain" toml:"explain"`
+	SummaryImage  string   `yaml:"summary_image" toml:"summary_image"`
+	GanttImage    string   `yaml:"gantt_image" toml:"gantt_image"`
+	Watch         bool     `yaml:"-" toml:"-"`
+	Merge         bool     `yaml:"-" toml:"-"`
+	Verbose       string   `yaml:"verbose" toml:"verbose"`
+	TraceFile     string   `yaml:"trace_file" toml:"trace_file"`
+	QueueGraph    string   `yaml:"queue_graph" toml:"queue_graph"`
+	EventLog      string   `yaml:"event_log" toml:"event_log"`
+	TimeUnit      string   `yaml:"time_unit" toml:"time_unit"`
+	TimeScale     float64  `yaml:"time_scale" toml:"time_scale"`
+	MaxRows       int      `yaml:"max_rows" toml:"max_rows"`
+	MaxSlices     int      `yaml:"max_gantt_slices" toml:"max_gantt_slices"`
+	ChapterRows   int      `yaml:"chapter_rows" toml:"chapter_rows"`
+	GanttRes      int64    `yaml:"gantt_resolution" toml:"gantt_resolution"`
+	SortBy        string   `yaml:"sort_by" toml:"sort_by"`
+	Columns       []string `yaml:"columns" toml:"columns"`
+	RunQueue      string   `yaml:"run_queue" toml:"run_queue"`
+	ArrivalTies   string   `yaml:"arrival_ties" toml:"arrival_ties"`
+	OnDuplicate   string   `yaml:"on_duplicate" toml:"on_duplicate"`
+	Stream        bool     `yaml:"stream" toml:"stream"`
+	Locale        string   `yaml:"locale" toml:"locale"`
+	// TranslateHeaders also translates table headers into the locale's
+	// language, when a translation exists.
+	TranslateHeaders bool   `yaml:"translate_headers" toml:"translate_headers"`
+	ResourceUsage    bool   `yaml:"resource_usage" toml:"resource_usage"`
+	CPUProfile       string `yaml:"-" toml:"-"`
+	HeapProfile      string `yaml:"-" toml:"-"`
+
+	// Epoch, when set, is the wall-clock time of tick 0, and Tick how
+	// long a tick lasts, so points in time render as timestamps.
+	Epoch time.Time     `yaml:"epoch" toml:"epoch"`
+	Tick  time.Duration `yaml:"tick" toml:"tick"`
+
+	// Resolution is how many simulation ticks make a time unit, so times
+	// such as 2.5 can be given with a resolution of 2 or 10. Zero means 1.
+	Resolution int64 `yaml:"resolution" toml:"resolution"`
+
+	// Seed drives what is pseudo-random in a run: the jitter of periodic
+	// tasks and the workloads a scenario or equivalence generates.
+	Seed int64 `yaml:"seed" toml:"seed"`
+
+	// Engines and Random are what equivalence compares: two engines, on
+	// the workloads given and this many generated ones.
+	Engines string `yaml:"-" toml:"-"`
+	Random  int    `yaml:"-" toml:"-"`
+
+	// Predicate and Exec are the failure minimize preserves: a built-in
+	// predicate, or a shell command that fails on the workload.
+	Predicate string `yaml:"-" toml:"-"`
+	Exec      string `yaml:"-" toml:"-"`
+
+	// Grid, when set, varies parameters together, running the algorithms
+	// at every combination of their values.
+	Grid map[string][]int64 `yaml:"grid" toml:"grid"`
+}
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+func defaultConfig() Config {
+	cfg := Config{Quantum: 2, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file"}
+	for _, s := range schedulers {
+		cfg.Algorithms = append(cfg.Algorithms, s.Name)
+	}
+	return cfg
+}
+
+// ticksPerUnit is the resolution, how many ticks make a time unit.
+func (c Config) ticksPerUnit() int64 {
+	return max(c.Resolution, 1)
+}
+
+// parseArgs parses the command line flags into a Config. The returned args
+// hold the binary name followed by the remaining positional arguments.
+// Precedence is defaults, then the --config file, then the settings of a
+// scenario workload, then explicit flags.
+func parseArgs(args ...string) (Config, []string, error) {
+	if len(args) == 0 {
+		return Config{}, nil, fmt.Errorf("%w: missing binary name", ErrInvalidArgs)
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var (
+		configPath = fs.String("config", "", "YAML, JSON or TOML file describing the simulation")
+		algorithms = fs.String("algorithms", "", "comma-separated schedulers to run (default all)")
+		quantum    ticksFlag
+		overhead   ticksFlag
+		ganttRes   ticksFlag
+		sweepSpec  = fs.String("sweep", "", "rerun tunable algorithms over a range, e.g. quantum=1..10")
+		sweepFmt   = fs.String("sweep-format", "", "sweep output format: table or csv (default table)")
+		color      = fs.Bool("color", false, "color each process consistently and print a legend (default on a terminal)")
+		noColor    = fs.Bool("no-color", false, "never color the output, as when NO_COLOR is set")
+		quiet      = fs.Bool("quiet", false, "print only the averages and throughput of each algorithm")
+		matrix     = fs.Bool("matrix", false, "also print each algorithm's timeline as a matrix of processes by ticks")
+		canonical  = fs.Bool("canonical", false, "print only a stable, minimal record of each schedule for automated grading")
+		tmpl       = fs.String("template", "", "write the results through a Go text/template file instead")
+		format     = fs.String("format", "", "write results as text, json, ndjson, csv, md (Markdown), plantuml, latex, trace-event (for Perfetto), xlsx or chapters (a text file per algorithm)")
+		outputDir  = fs.String("output-dir", "", "directory --format csv, xlsx and chapters write their files to (default the current one)")
+		compare    = fs.Bool("compare", false, "finish with a side-by-side comparison of the algorithms")
+		from       ticksFlag
+		to         ticksFlag
+		step       = fs.Bool("step", false, "pause after every scheduling decision")
+		explain    = fs.Bool("explain", false, "explain every scheduling decision in plain English before each schedule")
+		summaryImg = fs.String("summary-image", "", "write the algorithm comparison to an .svg or .png file")
+		ganttImg   = fs.String("gantt-image", "", "draw every algorithm's Gantt chart to a .png file")
+		watchFile  = fs.Bool("watch", false, "rerun the simulation whenever the workload file changes")
+		merge      = fs.Bool("merge", false, "combine several workload files into one simulation, namespacing their PIDs")
+		enginePair = fs.String("engines", "", "with equivalence, the two engines to compare (default event,tick)")
+		random     = fs.Int("random", 0, "with equivalence, also compare on this many generated workloads, seeded from --seed on")
+		predicate  = fs.String("predicate", "", "with minimize, the failure to preserve: invariants or run-queues (default invariants)")
+		command    = fs.String("exec", "", `with minimize, a shell command that fails (exits non-zero) on the workload CSV passed as "$1"`)
+		verbose    = fs.String("verbose", "", "trace scheduling decisions: a level 1-2, or per algorithm as rr=2,sjf=1")
+		traceFile  = fs.String("trace-file", "", "write the decision trace here instead of stderr")
+		queueGraph = fs.String("queue-graph", "", "draw the ready queue at every scheduling decision to a Graphviz .dot file")
+		eventLog   = fs.String("event-log", "", "write every event of the simulation, with the reason for each decision, to a file")
+		timeUnit   = fs.String("time-unit", "", "label times and rates with a unit: ticks, ms or s")
+		timeScale  = fs.Float64("time-scale", 0, "units per simulation tick when rendering times (default 1)")
+		resolution = fs.Int64("resolution", 0, "simulation ticks per time unit, to allow fractional times such as 2.5")
+		epoch      time.Time
+		tick       = fs.Duration("tick", 0, "wall-clock length of a tick when rendering timestamps, e.g. 250us")
+		maxRows    = fs.Int("max-rows", 0, "print at most this many schedule table rows (0 for all)")
+		chapterRws = fs.Int("chapter-rows", 0, "with --format chapters, split schedule tables into files of this many rows (0 for one file)")
+		maxSlices  = fs.Int("max-gantt-slices", 0, "print at most this many Gantt slices (0 for all)")
+		sortBy     = fs.String("sort-by", "", "sort the schedule table by a column, e.g. wait or turnaround:desc")
+		columns    = fs.String("columns", "", "comma-separated schedule table columns, e.g. id,wait,response")
+		stream     = fs.Bool("stream", false, "write the schedule table row by row, repeating the header, without buffering it")
+		locale     = fs.String("locale", "", "format numbers for a locale, e.g. de-DE")
+		translate  = fs.Bool("translate-headers", false, "translate table headers into the --locale language (de, es, fr)")
+		cpuProfile = fs.String("pprof", "", "write a CPU profile of the simulation to this file")
+		heapProf   = fs.String("pprof-heap", "", "write a heap profile taken after the simulation to this file")
+		usage      = fs.Bool("resource-usage", false, "report the time and memory each algorithm's run took on stderr")
+		runQueue   = fs.String("run-queue", "", "ready queue implementation for sjf and priority: list, heap, rbtree or bucket (default heap)")
+		ties       = fs.String("arrival-ties", "", "order of processes arriving at the same time: file or pid (default file)")
+		duplicates = fs.String("on-duplicate", "", "settle repeated process IDs: error, renumber or merge (default error)")
+		seed       = fs.Int64("seed", 0, "seed of the pseudo-random periodic task jitter")
+	)
+	fs.Var(&quantum, "quantum", "round-robin time quantum, in ticks or as a duration such as 150ms (default 2)")
+	fs.Var(&overhead, "sched-overhead", "CPU time the scheduler consumes per decision")
+	fs.Var(&ganttRes, "gantt-resolution", "coarsen Gantt charts to buckets this long, each showing the process that ran most in it")
+	fs.Var(&from, "from", "start of the rendered time window")
+	fs.Var(&to, "to", "end of the rendered time window (0 for the end of the run)")
+	// A Func flag, unlike TextVar, prints no zero time as its default.
+	fs.Func("epoch", "RFC 3339 wall-clock time of tick 0, to render points in time as timestamps", func(s string) error {
+		return epoch.UnmarshalText([]byte(s))
+	})
+	if err := parseFlags(fs, args[1:]); err != nil {
+		return Config{}, nil, err
+	}
+
+	cfg := defaultConfig()
+	cfg.Color = colorByDefault(os.Stdout, os.Getenv)
+	if *configPath != "" {
+		if err := loadConfigFile(*configPath, &cfg); err != nil {
+			return Config{}, nil, err
+		}
+	}
+	if rest := fs.Args(); len(rest) == 1 && isScenario(rest[0]) {
+		if _, err := loadScenario(rest[0], &cfg); err != nil {
+			return Config{}, nil, err
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "algorithms":
+			cfg.Algorithms = strings.Split(*algorithms, ",")
+		case "sweep":
+			cfg.Sweep = *sweepSpec
+		case "sweep-format":
+			cfg.SweepFormat = *sweepFmt
+		case "color":
+			cfg.Color = *color
+		case "no-color":
+			if *noColor {
+				cfg.Color = false
+			}
+		case "quiet":
+			cfg.Quiet = *quiet
+		case "matrix":
+			cfg.Matrix = *matrix
+		case "canonical":
+			cfg.Canonical = *canonical
+		case "format":
+			cfg.Format = *format
+		case "template":
+			cfg.Template = *tmpl
+		case "output-dir":
+			cfg.OutputDir = *outputDir
+		case "compare":
+			cfg.Compare = *compare
+		case "step":
+			cfg.Step = *step
+		case "explain":
+			cfg.Explain = *explain
+		case "summary-image":
+			cfg.SummaryImage = *summaryImg
+		case "gantt-image":
+			cfg.GanttImage = *ganttImg
+		case "watch":
+			cfg.Watch = *watchFile
+		case "merge":
+			cfg.Merge = *merge
+		case "engines":
+			cfg.Engines = *enginePair
+		case "random":
+			cfg.Random = *random
+		case "predicate":
+			cfg.Predicate = *predicate
+		case "exec":
+			cfg.Exec = *command
+		case "verbose":
+			cfg.Verbose = *verbose
+		case "trace-file":
+			cfg.TraceFile = *traceFile
+		case "queue-graph":
+			cfg.QueueGraph = *queueGraph
+		case "event-log":
+			cfg.EventLog = *eventLog
+		case "time-unit":
+			cfg.TimeUnit = *timeUnit
+		case "time-scale":
+			cfg.TimeScale = *timeScale
+		case "resolution":
+			cfg.Resolution = *resolution
+		case "epoch":
+			cfg.Epoch = epoch
+		case "tick":
+			cfg.Tick = *tick
+		case "max-rows":
+			cfg.MaxRows = *maxRows
+		case "chapter-rows":
+			cfg.ChapterRows = *chapterRws
+		case "max-gantt-slices":
+			cfg.MaxSlices = *maxSlices
+		case "sort-by":
+			cfg.SortBy = *sortBy
+		case "columns":
+			cfg.Columns = strings.Split(*columns, ",")
+		case "run-queue":
+			cfg.RunQueue = *runQueue
+		case "arrival-ties":
+			cfg.ArrivalTies = *ties
+		case "on-duplicate":
+			cfg.OnDuplicate = *duplicates
+		case "seed":
+			cfg.Seed = *seed
+		case "stream":
+			cfg.Stream = *stream
+		case "locale":
+			cfg.Locale = *locale
+		case "translate-headers":
+			cfg.TranslateHeaders = *translate
+		case "resource-usage":
+			cfg.ResourceUsage = *usage
+		case "pprof":
+			cfg.CPUProfile = *cpuProfile
+		case "pprof-heap":
+			cfg.HeapProfile = *heapProf
+		}
+	})
+	// Times are converted to ticks once the tick and resolution are
+	// settled: flags as they are read, and the config's own times, given in
+	// units, by scaling.
+	timed := []struct {
+		name  string
+		flag  *ticksFlag
+		field *int64
+	}{
+		{"quantum", &quantum, &cfg.Quantum},
+		{"sched-overhead", &overhead, &cfg.SchedOverhead},
+		{"from", &from, &cfg.From},
+		{"to", &to, &cfg.To},
+		{"gantt-resolution", &ganttRes, &cfg.GanttRes},
+	}
+	for _, t := range timed {
+		if t.flag.raw == "" {
+			*t.field *= cfg.ticksPerUnit()
+			continue
+		}
+		v, err := t.flag.ticks(cfg.loadOptions())
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("%w: --%s: %v", ErrInvalidArgs, t.name, err)
+		}
+		*t.field = v
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, nil, err
+	}
+
+	return cfg, append([]string{args[0]}, fs.Args()...), nil
+}
+
+// parseFlags parses args with fs. When -h or --help asks for it, the
+// flags' usage is printed on stderr and flag.ErrHelp returned as is, which
+// fail treats as success.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", fs.Name())
+		fs.SetOutput(os.Stderr)
+		fs.PrintDefaults()
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	return nil
+}
+
+// loadConfigFile decodes a YAML (or JSON, being a subset) or TOML config
+// file over cfg, so keys left out of the file keep their current values.
+func loadConfigFile(path string, cfg *Config) error {
+	if isTOML(path) {
+		return loadTOMLConfig(path, cfg)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%v: error opening config file", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+	return nil
+}
+
+func isTOML(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".toml"
+}
+
+// loadTOMLConfig decodes the settings of a TOML config file over cfg. A
+// scenario's defaults and processes are left to the workload loader; any
+// other unknown key is an error.
+func loadTOMLConfig(path string, cfg *Config) error {
+	md, err := toml.DecodeFile(path, cfg)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+	for _, key := range md.Undecoded() {
+		if top := key[0]; top != "defaults" && top != "processes" {
+			return fmt.Errorf("%w: %s: unknown key %q", ErrInvalidConfig, path, key.String())
+		}
+	}
+	return nil
+}
+
+func (c Config) validate() error {
+	if len(c.Algorithms) == 0 {
+		return fmt.Errorf("%w: no algorithms selected", ErrInvalidConfig)
+	}
+	for _, name := range c.Algorithms {
+		if _, ok := lookupScheduler(name); !ok {
+			return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidConfig, name)
+		}
+	}
+	if c.GanttRes < 0 {
+		return fmt.Errorf("%w: Gantt resolution must not be negative, got %d", ErrInvalidConfig, c.GanttRes)
+	}
+	if c.Quantum <= 0 {
+		return fmt.Errorf("%w: quantum must be positive, got %d", ErrInvalidConfig, c.Quantum)
+	}
+	if _, ok := runQueues[c.RunQueue]; !ok {
+		return fmt.Errorf("%w: unknown run queue %q", ErrInvalidConfig, c.RunQueue)
+	}
+	if _, ok := arrivalTies[c.ArrivalTies]; !ok {
+		return fmt.Errorf("%w: unknown arrival tie order %q, want file or pid", ErrInvalidConfig, c.ArrivalTies)
+	}
+	if !duplicatePolicies[c.OnDuplicate] {
+		return fmt.Errorf("%w: unknown duplicate policy %q, want error, renumber or merge", ErrInvalidConfig, c.OnDuplicate)
+	}
+	if c.SchedOverhead < 0 {
+		return fmt.Errorf("%w: scheduler overhead must not be negative, got %d", ErrInvalidConfig, c.SchedOverhead)
+	}
+	if c.MaxRows < 0 || c.MaxSlices < 0 || c.ChapterRows < 0 {
+		return fmt.Errorf("%w: output limits must not be negative", ErrInvalidConfig)
+	}
+	if c.From < 0 || (c.To != 0 && c.To <= c.From) {
+		return fmt.Errorf("%w: empty time window %d..%d", ErrInvalidConfig, c.From, c.To)
+	}
+	if ext := strings.ToLower(filepath.Ext(c.SummaryImage)); c.SummaryImage != "" && ext != ".svg" && ext != ".png" {
+		return fmt.Errorf("%w: summary image must be .svg or .png, got %q", ErrInvalidConfig, c.SummaryImage)
+	}
+	if c.GanttImage != "" && strings.ToLower(filepath.Ext(c.GanttImage)) != ".png" {
+		return fmt.Errorf("%w: Gantt image must be .png, got %q", ErrInvalidConfig, c.GanttImage)
+	}
+	if _, err := parseColumns(c.Columns); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if _, err := parseSortKey(c.SortBy); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if _, err := parseVerbosity(c.Verbose); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if c.Sweep != "" {
+		if _, err := parseSweep(c.Sweep); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+	if len(c.Grid) > 0 {
+		if c.Sweep != "" {
+			return fmt.Errorf("%w: a sweep and a grid cannot be combined", ErrInvalidConfig)
+		}
+		if _, err := parseGrid(c.Grid); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+	if c.SweepFormat != "table" && c.SweepFormat != "csv" {
+		return fmt.Errorf("%w: unknown sweep format %q", ErrInvalidConfig, c.SweepFormat)
+	}
+	if _, ok := timeUnitLabels[c.TimeUnit]; !ok {
+		return fmt.Errorf("%w: unknown time unit %q", ErrInvalidConfig, c.TimeUnit)
+	}
+	if c.Locale != "" {
+		if _, err := language.Parse(c.Locale); err != nil {
+			return fmt.Errorf("%w: locale %q: %v", ErrInvalidConfig, c.Locale, err)
+		}
+	}
+	if _, ok := resultFormats[c.Format]; !ok {
+		return fmt.Errorf("%w: unknown format %q, want text, json, ndjson, csv, md, plantuml, latex, trace-event, xlsx or chapters", ErrInvalidConfig, c.Format)
+	}
+	if resultFormats[c.Format].custom() && (c.Canonical || c.Sweep != "" || len(c.Grid) > 0) {
+		return fmt.Errorf("%w: --format %s cannot be combined with --canonical, a sweep or a grid", ErrInvalidConfig, c.Format)
+	}
+	if c.Template != "" {
+		if resultFormats[c.Format].custom() || c.Canonical || c.Sweep != "" || len(c.Grid) > 0 {
+			return fmt.Errorf("%w: --template cannot be combined with --format %s, --canonical, a sweep or a grid", ErrInvalidConfig, c.Format)
+		}
+		if _, err := parseTemplate(c.Template, c.numberFormat()); err != nil {
+			return err
+		}
+	}
+	if c.Explain && (c.resultFormat().custom() || c.Canonical) {
+		return fmt.Errorf("%w: --explain needs the text output, so cannot be combined with --format %s, --template or --canonical", ErrInvalidConfig, c.Format)
+	}
+	if c.Merge && c.Watch {
+		return fmt.Errorf("%w: --merge cannot be combined with --watch", ErrInvalidConfig)
+	}
+	if c.Resolution < 0 {
+		return fmt.Errorf("%w: resolution must not be negative, got %d", ErrInvalidConfig, c.Resolution)
+	}
+	if c.TimeScale <= 0 {
+		return fmt.Errorf("%w: time scale must be positive, got %g", ErrInvalidConfig, c.TimeScale)
+	}
+	if !c.Epoch.IsZero() && c.Tick <= 0 {
+		return fmt.Errorf("%w: an epoch needs a positive tick duration, got %v", ErrInvalidConfig, c.Tick)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseArgs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		p := path.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	yamlConfig := writeConfig("sim.yaml", "algorithms: [rr, sjf]\nquantum: 4\n")
+	jsonConfig := writeConfig("sim.json", `{"algorithms": ["fcfs"], "quantum": 3}`)
+	typoConfig := writeConfig("typo.yaml", "quantom: 4\n")
+	tomlConfig := writeConfig("sim.toml", "algorithms = [\"sjf\"]\nquantum = 5\n")
+	scenario := writeConfig("scenario.toml", "quantum = 6\n\n[[processes]]\npid = 1\nburst = 2\narrival = 0\n")
+	typoScenario := writeConfig("typo.toml", "quantom = 6\n")
+	gridScenario := writeConfig("grid.toml", "[grid]\nquantum = [1, 2]\n")
+	cpuGrid := writeConfig("cpus.toml", "[grid]\ncpus = [1, 2]\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     Config
+		wantArgs []string
+		wantErr  error
+	}{
+		{
+			name:     "defaults",
+			args:     []string{"binary_name", "file.csv"},
+			want:     defaultConfig(),
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "yaml config",
+			args:     []string{"binary_name", "--config", yamlConfig, "file.csv"},
+			want:     Config{Algorithms: []string{"rr", "sjf"}, Quantum: 4, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file"},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "json config",
+			args:     []string{"binary_name", "--config", jsonConfig, "file.csv"},
+			want:     Config{Algorithms: []string{"fcfs"}, Quantum: 3, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file"},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "flags override config",
+			args:     []string{"binary_name", "--config", yamlConfig, "--quantum", "1", "file.csv"},
+			want:     Config{Algorithms: []string{"rr", "sjf"}, Quantum: 1, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file"},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:     "toml config",
+			args:     []string{"binary_name", "--config", tomlConfig, "file.csv"},
+			want:     Config{Algorithms: []string{"sjf"}, Quantum: 5, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file"},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name: "toml scenario settings override config",
+			args: []string{"binary_name", "--config", tomlConfig, scenario},
+			want: Config{
+				Algorithms: []string{"sjf"}, Quantum: 6, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file",
+			},
+			wantArgs: []string{"binary_name", scenario},
+		},
+		{
+			name: "toml scenario grid",
+			args: []string{"binary_name", gridScenario},
+			want: Config{
+				Algorithms: defaultConfig().Algorithms, Quantum: 2, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file",
+				Grid: map[string][]int64{"quantum": {1, 2}},
+			},
+			wantArgs: []string{"binary_name", gridScenario},
+		},
+		{
+			name:    "grid over cpus",
+			args:    []string{"binary_name", cpuGrid},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "grid with a sweep",
+			args:    []string{"binary_name", "--sweep", "quantum=1..3", gridScenario},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown toml scenario key",
+			args:    []string{"binary_name", typoScenario},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown config key",
+			args:    []string{"binary_name", "--config", typoConfig, "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown algorithm",
+			args:    []string{"binary_name", "--algorithms", "lottery", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "bad quantum",
+			args:    []string{"binary_name", "--quantum", "0", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"binary_name", "--format", "yaml", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "format with canonical output",
+			args:    []string{"binary_name", "--format", "json", "--canonical", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "unknown time unit",
+			args:    []string{"binary_name", "--time-unit", "fortnight", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name: "durations in ticks",
+			args: []string{"binary_name", "--tick", "50ms", "--quantum", "150ms", "--to", "1s", "file.csv"},
+			want: Config{
+				Algorithms: defaultConfig().Algorithms, Quantum: 3, To: 20, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file",
+				Tick: 50 * time.Millisecond,
+			},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name: "times in units at a resolution",
+			args: []string{"binary_name", "--config", yamlConfig, "--resolution", "10", "--to", "2.5", "file.csv"},
+			want: Config{
+				Algorithms: []string{"rr", "sjf"}, Quantum: 40, To: 25, SweepFormat: "table", TimeScale: 1, RunQueue: defaultRunQueue, ArrivalTies: "file",
+				Resolution: 10,
+			},
+			wantArgs: []string{"binary_name", "file.csv"},
+		},
+		{
+			name:    "time finer than the resolution",
+			args:    []string{"binary_name", "--resolution", "2", "--quantum", "0.25", "file.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "duration without a tick",
+			args:    []string{"binary_name", "--quantum", "150ms", "file.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "epoch without a tick",
+			args:    []string{"binary_name", "--epoch", "2024-05-01T14:03:05Z", "file.csv"},
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "malformed epoch",
+			args:    []string{"binary_name", "--epoch", "14:03", "--tick", "1ms", "file.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"binary_name", "--nope", "file.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "help",
+			args:    []string{"binary_name", "--help"},
+			wantErr: flag.ErrHelp,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotArgs, err := parseArgs(tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseArgs() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseArgs() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("parseArgs() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
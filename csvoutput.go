@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// outputCSV writes a run's results as CSV files for spreadsheets: one per
+// algorithm, named after it, holding its schedule table, and metrics.csv
+// with a row of averages and totals per algorithm. The files go in
+// cfg.OutputDir, and w lists them. Values are in the time unit, as the
+// tables print them, but without locale digit grouping, so spreadsheets
+// read them as numbers; values a process does not have are left empty.
+func outputCSV(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	dir := cfg.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%v: error creating output directory", err)
+	}
+	opts := cfg.outputOptions()
+	for _, row := range summary {
+		if err := writeCSVFile(w, filepath.Join(dir, row.Scheduler.Name+".csv"), scheduleRecords(row, opts)); err != nil {
+			return err
+		}
+	}
+	return writeCSVFile(w, filepath.Join(dir, "metrics.csv"), metricsRecords(summary, metricPlugins, opts.numbers))
+}
+
+// scheduleRecords lays an algorithm's schedule table out for spreadsheets,
+// header row first, as outputCSV describes.
+func scheduleRecords(row summaryRow, opts outputOptions) [][]string {
+	nf := opts.numbers
+	nf.printer = nil
+	columns := opts.columns
+	if columns == nil {
+		columns = defaultColumnsFor(row.Result.Schedule)
+	}
+	records := [][]string{make([]string, len(columns))}
+	for i, c := range columns {
+		records[0][i] = nf.header(c.Header)
+	}
+	for _, p := range opts.sortBy.sort(row.Result.Schedule) {
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			if c.Present == nil || c.Present(p) {
+				record[i] = c.format(p, nf)
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// metricsRecords lays out a row of averages and totals per algorithm for
+// spreadsheets, header row first, followed by a column per custom metric.
+func metricsRecords(summary []summaryRow, metrics []Metric, nf numberFormat) [][]string {
+	nf.printer = nil
+	header := []string{
+		nf.header("Algorithm"), nf.header("Avg wait"), nf.header("Avg turnaround"), nf.header("Avg response"),
+		nf.header("Throughput") + " (/" + nf.label() + ")", nf.header("Context switches"), nf.header("Makespan"),
+	}
+	for _, m := range metrics {
+		header = append(header, nf.header(m.Name))
+	}
+	records := [][]string{header}
+	for _, row := range summary {
+		r := row.Result
+		record := []string{
+			row.Scheduler.Name, nf.duration(r.AveWait), nf.duration(r.AveTurnaround), nf.duration(r.AveResponse),
+			nf.float(r.AveThroughput / nf.units(1)), nf.int(int64(r.ContextSwitches)), nf.time(r.Makespan),
+		}
+		for _, m := range metrics {
+			var cell string
+			if v, ok := r.Metrics[m.Name]; ok {
+				cell = m.format(v, nf)
+			}
+			record = append(record, cell)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// writeCSVFile creates path holding records, and notes it on w.
+func writeCSVFile(w io.Writer, path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating CSV file", err)
+	}
+	cw := csv.NewWriter(f)
+	_ = cw.WriteAll(records)
+	if err := cw.Error(); err != nil {
+		f.Close()
+		return fmt.Errorf("%v: error writing %s", err, path)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%v: error writing %s", err, path)
+	}
+	_, _ = fmt.Fprintf(w, "wrote %s\n", path)
+	return nil
+}
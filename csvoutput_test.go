@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_outputCSV(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "results")
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2, Deadline: 4},
+		{ProcessID: 2, BurstDuration: 1500, ArrivalTime: 1, Priority: 1},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs"}
+	cfg.Format = "csv"
+	cfg.OutputDir = dir
+	cfg.Locale = "en-US"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "fcfs.csv",
+			want: "ID,Priority,Burst,Arrival,Wait,Turnaround,Exit,Missed by\n1,2,5,0,0,5,5,1\n2,1,1500,1,4,1504,1505,\n",
+		},
+		{
+			name: "metrics.csv",
+			want: "Algorithm,Avg wait,Avg turnaround,Avg response,Throughput (/t),Context switches,Makespan\nfcfs,2.00,754.50,2.00,0.00,1,1505\n",
+		},
+	}
+	wantListing := ""
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		wantListing += "wrote " + path + "\n"
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != f.want {
+			t.Errorf("%s = %q, want %q", f.name, got, f.want)
+		}
+	}
+	if w.String() != wantListing {
+		t.Errorf("run() = %q, want %q", w.String(), wantListing)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseDependencies reads the PIDs a process depends on, separated by
+// commas or spaces, such as "1,2" or "1 2". An empty list means none.
+func parseDependencies(s string) ([]int64, error) {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	deps := make([]int64, len(parts))
+	for i, part := range parts {
+		pid, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || pid < 0 {
+			return nil, fmt.Errorf("dependency %q is not a process ID", part)
+		}
+		deps[i] = pid
+	}
+	return deps, nil
+}
+
+// formatDependencies writes deps in the form parseDependencies reads.
+func formatDependencies(deps []int64) string {
+	parts := make([]string, len(deps))
+	for i, pid := range deps {
+		parts[i] = strconv.FormatInt(pid, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// dependencyProblems checks the dependencies of a whole workload: each
+// must name another process in it, and none may lead back round to the
+// process that declares it, which could then never run.
+func dependencyProblems(processes []Process) []string {
+	byPID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byPID[p.ProcessID] = p
+	}
+	var msgs []string
+	for _, p := range processes {
+		for _, dep := range p.DependsOn {
+			switch _, ok := byPID[dep]; {
+			case dep == p.ProcessID:
+				msgs = append(msgs, fmt.Sprintf("process %d depends on itself", p.ProcessID))
+			case !ok:
+				msgs = append(msgs, fmt.Sprintf("process %d depends on unknown process %d", p.ProcessID, dep))
+			}
+		}
+	}
+	if len(msgs) > 0 {
+		return msgs
+	}
+
+	// Depth-first search, reporting each cycle once from its smallest PID.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	var (
+		state = make(map[int64]int, len(processes))
+		path  []int64
+		visit func(pid int64)
+	)
+	visit = func(pid int64) {
+		state[pid] = visiting
+		path = append(path, pid)
+		for _, dep := range byPID[pid].DependsOn {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := len(path) - 1
+				for path[start] != dep {
+					start--
+				}
+				cycle := append(path[start:len(path):len(path)], dep)
+				msgs = append(msgs, "dependency cycle: "+strings.ReplaceAll(formatDependencies(cycle), ",", " depends on "))
+			}
+		}
+		path = path[:len(path)-1]
+		state[pid] = visited
+	}
+	pids := make([]int64, 0, len(byPID))
+	for pid := range byPID {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+	for _, pid := range pids {
+		if state[pid] == unvisited {
+			visit(pid)
+		}
+	}
+	return msgs
+}
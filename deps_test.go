@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseDependencies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []int64
+		wantErr bool
+	}{
+		{name: "empty", s: " "},
+		{name: "commas", s: "1,2", want: []int64{1, 2}},
+		{name: "spaces", s: "3 4, 5", want: []int64{3, 4, 5}},
+		{name: "not a pid", s: "1,x", wantErr: true},
+		{name: "negative", s: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDependencies(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDependencies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dependencyProblems(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []string
+	}{
+		{
+			name: "a valid graph",
+			processes: []Process{
+				{ProcessID: 1},
+				{ProcessID: 2, DependsOn: []int64{1}},
+				{ProcessID: 3, DependsOn: []int64{1, 2}},
+			},
+		},
+		{
+			name: "unknown and self",
+			processes: []Process{
+				{ProcessID: 1, DependsOn: []int64{9}},
+				{ProcessID: 2, DependsOn: []int64{2}},
+			},
+			want: []string{"process 1 depends on unknown process 9", "process 2 depends on itself"},
+		},
+		{
+			name: "cycle",
+			processes: []Process{
+				{ProcessID: 3, DependsOn: []int64{1}},
+				{ProcessID: 1, DependsOn: []int64{2}},
+				{ProcessID: 2, DependsOn: []int64{3}},
+				{ProcessID: 4, DependsOn: []int64{1}},
+			},
+			want: []string{"dependency cycle: 1 depends on 2 depends on 3 depends on 1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := dependencyProblems(tt.processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependencyProblems() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// duplicatePolicies settle processes that repeat an earlier process's ID.
+// The empty policy means error.
+var duplicatePolicies = map[string]bool{"": true, "error": true, "renumber": true, "merge": true}
+
+// loadOptions are the settings workloads are read with.
+type loadOptions struct {
+	// tick converts times given as durations.
+	tick time.Duration
+	// resolution is how many ticks make a time unit; 0 means 1.
+	resolution int64
+	// onDuplicate is the duplicatePolicies entry for repeated PIDs.
+	onDuplicate string
+	// seed draws the jitter of periodic tasks.
+	seed int64
+}
+
+func (c Config) loadOptions() loadOptions {
+	return loadOptions{tick: c.Tick, resolution: c.Resolution, onDuplicate: c.OnDuplicate, seed: c.Seed}
+}
+
+// pidSet collects a workload's processes as they are read, settling
+// repeated PIDs by policy: error reports them, renumber gives each repeat
+// a fresh PID after the largest in the workload, and merge folds it into
+// the process first given that PID.
+type pidSet struct {
+	policy    string
+	processes []Process
+	index     map[int64]int // of each PID's first process
+	at        map[int64]int // where each PID was first read
+	repeats   []int         // indexes of processes to renumber
+}
+
+func newPIDSet(policy string) *pidSet {
+	return &pidSet{policy: policy, processes: make([]Process, 0), index: make(map[int64]int), at: make(map[int64]int)}
+}
+
+// add takes p, read at position at, such as its line. If p repeats a PID
+// and the policy is error, p is left out and the position the PID was
+// first read at returned.
+func (s *pidSet) add(p Process, at int) (int, error) {
+	i, ok := s.index[p.ProcessID]
+	switch {
+	case !ok:
+		s.index[p.ProcessID], s.at[p.ProcessID] = len(s.processes), at
+	case s.policy == "renumber":
+		s.repeats = append(s.repeats, len(s.processes))
+	case s.policy == "merge":
+		return 0, mergeProcess(&s.processes[i], p)
+	default:
+		return s.at[p.ProcessID], nil
+	}
+	s.processes = append(s.processes, p)
+	return 0, nil
+}
+
+// workload returns the processes, with any repeats renumbered.
+func (s *pidSet) workload() []Process {
+	var next int64
+	for _, p := range s.processes {
+		next = max(next, p.ProcessID+1)
+	}
+	for _, i := range s.repeats {
+		s.processes[i].ProcessID = next
+		next++
+	}
+	return s.processes
+}
+
+// mergeProcess folds p into into, a process with the same PID: it arrives
+// with the earlier of the two and its burst is the sum of theirs.
+// Processes with bursts cannot be merged, having no gap to join them by.
+func mergeProcess(into *Process, p Process) error {
+	if into.Bursts != nil || p.Bursts != nil {
+		return fmt.Errorf("duplicate process ID %d cannot be merged, having bursts", p.ProcessID)
+	}
+	into.ArrivalTime = min(into.ArrivalTime, p.ArrivalTime)
+	into.BurstDuration += p.BurstDuration
+	for _, dep := range p.DependsOn {
+		if !slices.Contains(into.DependsOn, dep) {
+			into.DependsOn = append(into.DependsOn, dep)
+		}
+	}
+	return nil
+}
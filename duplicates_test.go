@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseWorkload_duplicates(t *testing.T) {
+	t.Parallel()
+	const input = "pid,burst,arrival,depends_on\n4,5,2,\n2,3,0,\n4,1,1,2\n2,6,3,\n"
+	tests := []struct {
+		policy       string
+		input        string
+		want         []Process
+		wantProblems []workloadProblem
+	}{
+		{
+			policy: "error",
+			input:  input,
+			want: []Process{
+				{ProcessID: 4, BurstDuration: 5, ArrivalTime: 2},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 0},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 4, Column: 1, Msg: "duplicate process ID 4, first defined on line 2"},
+				{Line: 5, Column: 1, Msg: "duplicate process ID 2, first defined on line 3"},
+			},
+		},
+		{
+			policy: "renumber",
+			input:  input,
+			want: []Process{
+				{ProcessID: 4, BurstDuration: 5, ArrivalTime: 2},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 5, BurstDuration: 1, ArrivalTime: 1, DependsOn: []int64{2}},
+				{ProcessID: 6, BurstDuration: 6, ArrivalTime: 3},
+			},
+			wantProblems: []workloadProblem{},
+		},
+		{
+			policy: "merge",
+			input:  input,
+			want: []Process{
+				{ProcessID: 4, BurstDuration: 6, ArrivalTime: 1, DependsOn: []int64{2}},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 0},
+			},
+			wantProblems: []workloadProblem{},
+		},
+		{
+			policy: "merge",
+			input:  "pid,arrival,bursts\n1,0,cpu:2\n1,4,\"cpu:1,io:2,cpu:1\"\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 2, Bursts: []Burst{{Duration: 2}}},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Msg: "duplicate process ID 1 cannot be merged, having bursts"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+			got, problems := parseWorkload(strings.NewReader(tt.input), loadOptions{onDuplicate: tt.policy})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkload() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(problems, tt.wantProblems) {
+				t.Errorf("parseWorkload() problems = %v, want %v", problems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeFields marks the workload fields that hold times, which can be
+// given as durations such as 150ms as well as in time units.
+var timeFields = map[int]bool{1: true, 2: true, 4: true, periodField: true, jitterField: true}
+
+// parseTicks reads a time in units of opts.resolution ticks each, or as a
+// duration such as "150ms", "2s" or "5m" that is converted to ticks of
+// length opts.tick / opts.resolution. A unit can be split, as in "2.5",
+// only as finely as the resolution allows. A duration needs a tick length
+// and must be a whole number of ticks.
+func parseTicks(s string, opts loadOptions) (int64, error) {
+	s = strings.TrimSpace(s)
+	res := max(opts.resolution, 1)
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v * res, nil
+	}
+	if strings.Contains(s, ".") {
+		if r, ok := new(big.Rat).SetString(s); ok {
+			r.Mul(r, new(big.Rat).SetInt64(res))
+			if !r.IsInt() || !r.Num().IsInt64() {
+				if res == 1 {
+					return 0, fmt.Errorf("must be a whole number of ticks, got %q; a --resolution above 1 allows fractions", s)
+				}
+				return 0, fmt.Errorf("must be a whole number of 1/%d units (the --resolution), got %q", res, s)
+			}
+			return r.Num().Int64(), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer or a duration such as 150ms, got %q", s)
+	}
+	if opts.tick <= 0 {
+		return 0, fmt.Errorf("%q is a duration, but no tick length is set (--tick)", s)
+	}
+	if d*time.Duration(res)%opts.tick != 0 {
+		return 0, fmt.Errorf("%q is not a whole number of %v ticks", s, opts.tick/time.Duration(res))
+	}
+	return int64(d * time.Duration(res) / opts.tick), nil
+}
+
+// ticksFlag is a flag given in time units or as a duration. Values are
+// only converted by ticks, once the tick length and resolution are
+// settled.
+type ticksFlag struct {
+	raw string
+}
+
+func (f *ticksFlag) String() string { return f.raw }
+
+func (f *ticksFlag) Set(s string) error {
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		if _, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("must be an integer or a duration such as 150ms, got %q", s)
+		}
+	}
+	f.raw = s
+	return nil
+}
+
+// ticks converts the flag's value as parseTicks does.
+func (f *ticksFlag) ticks(opts loadOptions) (int64, error) {
+	return parseTicks(f.raw, opts)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseTicks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		opts    loadOptions
+		want    int64
+		wantErr bool
+	}{
+		{name: "ticks", s: " 42 ", want: 42},
+		{name: "milliseconds", s: "150ms", opts: loadOptions{tick: 50 * time.Millisecond}, want: 3},
+		{name: "minutes", s: "5m", opts: loadOptions{tick: time.Second}, want: 300},
+		{name: "no tick", s: "2s", wantErr: true},
+		{name: "not a whole number of ticks", s: "120ms", opts: loadOptions{tick: 50 * time.Millisecond}, wantErr: true},
+		{name: "neither", s: "soon", opts: loadOptions{tick: time.Second}, wantErr: true},
+		{name: "units at a resolution", s: "42", opts: loadOptions{resolution: 10}, want: 420},
+		{name: "fraction", s: "2.5", opts: loadOptions{resolution: 10}, want: 25},
+		{name: "fraction without a resolution", s: "2.5", wantErr: true},
+		{name: "fraction finer than the resolution", s: "2.25", opts: loadOptions{resolution: 2}, wantErr: true},
+		{name: "whole fraction", s: "3.0", want: 3},
+		{name: "duration at a resolution", s: "2500us", opts: loadOptions{tick: time.Millisecond, resolution: 2}, want: 5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTicks(tt.s, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTicks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTicks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseWorkload_durations(t *testing.T) {
+	t.Parallel()
+	input := "pid,burst,arrival,deadline,priority\n1,150ms,0,1s,2\n2,2,1s,0,1\n3,5,0,0,1ms\n"
+	got, problems := parseWorkload(strings.NewReader(input), loadOptions{tick: 50 * time.Millisecond})
+
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0, Deadline: 20, Priority: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 20, Priority: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorkload() = %v, want %v", got, want)
+	}
+	wantProblems := []workloadProblem{{Line: 4, Column: 5, Msg: `priority must be an integer, got "1ms"`}}
+	if !reflect.DeepEqual(problems, wantProblems) {
+		t.Errorf("parseWorkload() problems = %v, want %v", problems, wantProblems)
+	}
+}
+
+func Test_parseWorkload_fractions(t *testing.T) {
+	t.Parallel()
+	opts := loadOptions{resolution: 10}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 25, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 12, ArrivalTime: 5},
+	}
+	got, problems := parseWorkload(strings.NewReader("pid,burst,arrival\n1,2.5,0\n2,1.2,0.5\n3,1.25,1\n"), opts)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorkload() = %v, want %v", got, want)
+	}
+	wantProblems := []workloadProblem{{Line: 4, Column: 2, Msg: `burst duration must be a whole number of 1/10 units (the --resolution), got "1.25"`}}
+	if !reflect.DeepEqual(problems, wantProblems) {
+		t.Errorf("parseWorkload() problems = %v, want %v", problems, wantProblems)
+	}
+
+	got, problems = parseYAMLWorkload(strings.NewReader("processes:\n  - {pid: 1, burst: 2.5, arrival: 0}\n  - {pid: 2, burst: 1.2, arrival: 0.5}\n"), opts)
+	if !reflect.DeepEqual(got, want) || len(problems) > 0 {
+		t.Errorf("parseYAMLWorkload() = %v, %v, want %v", got, problems, want)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// engine runs an algorithm over processes. Every engine must produce the
+// same Result for the same inputs; only speed may differ.
+type engine func(processes []Process, s Scheduler, cfg Config) Result
+
+var engines = map[string]engine{
+	"event": func(processes []Process, s Scheduler, cfg Config) Result {
+		opts := cfg.simOptions()
+		opts.metrics = nil
+		return simulate(processes, s.NewPolicy(cfg), opts)
+	},
+	"tick": simulateTicks,
+}
+
+// equivalenceWorkload is one named workload the engines are compared on.
+type equivalenceWorkload struct {
+	name      string
+	processes []Process
+}
+
+// equivalence runs every selected algorithm under two engines, on the
+// given workload files and on --random generated ones, and fails unless
+// the results are identical. It reports the first difference of each
+// mismatch. It accepts the same flags as a regular run, of which those
+// that shape the simulation apply, and generated workloads are seeded
+// from --seed on.
+func equivalence(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"equivalence"}, args...)...)
+	if err != nil {
+		return err
+	}
+	if cfg.Engines == "" {
+		cfg.Engines = "event,tick"
+	}
+	names := strings.Split(cfg.Engines, ",")
+	if len(names) != 2 {
+		return fmt.Errorf("%w: --engines takes two engines, got %q", ErrInvalidArgs, cfg.Engines)
+	}
+	var compared [2]engine
+	for i, name := range names {
+		e, ok := engines[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown engine %q, want event or tick", ErrInvalidArgs, name)
+		}
+		compared[i] = e
+	}
+	if slices.Contains(names, "tick") {
+		for _, name := range cfg.Algorithms {
+			if _, ok := referenceRules[name]; !ok {
+				return fmt.Errorf("%w: the tick engine has no reference for %s", ErrInvalidArgs, name)
+			}
+		}
+	}
+	paths := args[1:]
+	if len(paths) == 0 && cfg.Random <= 0 {
+		return fmt.Errorf("%w: give workload files, --random or both", ErrInvalidArgs)
+	}
+
+	workloads := make([]equivalenceWorkload, 0, len(paths)+max(cfg.Random, 0))
+	for _, path := range paths {
+		f, closeFile, err := openProcessingFile("equivalence", path)
+		if err != nil {
+			return err
+		}
+		processes, err := loadWorkload(f.Name(), f, cfg.loadOptions())
+		closeFile()
+		if err != nil {
+			return err
+		}
+		workloads = append(workloads, equivalenceWorkload{name: path, processes: processes})
+	}
+	spec := generatorSpec{Count: 10, MaxArrival: 20, MaxBurst: 10, MaxPriority: 50}
+	for i := 0; i < cfg.Random; i++ {
+		s := cfg.Seed + int64(i)
+		workloads = append(workloads, equivalenceWorkload{
+			name:      fmt.Sprintf("seed %d", s),
+			processes: generateProcesses(rand.New(rand.NewSource(s)), spec),
+		})
+	}
+
+	var checks, mismatches int
+	for _, wl := range workloads {
+		for _, name := range cfg.Algorithms {
+			s, _ := lookupScheduler(name)
+			a := compared[0](wl.processes, s, cfg)
+			b := compared[1](wl.processes, s, cfg)
+			checks++
+			if diff := resultDifference(a, b); diff != "" {
+				mismatches++
+				_, _ = fmt.Fprintf(w, "%s: %s: %s differs from %s in %s\n", wl.name, name, names[1], names[0], diff)
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%d checks, %d mismatches between the %s and %s engines\n", checks, mismatches, names[0], names[1])
+	if mismatches > 0 {
+		return fmt.Errorf("%w: %d of %d checks differ", ErrAssertion, mismatches, checks)
+	}
+	return nil
+}
+
+// resultDifference describes the first field in which a and b differ, and
+// for lists the first differing entry, or returns "" if they are equal.
+func resultDifference(a, b Result) string {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		fa, fb := va.Field(i), vb.Field(i)
+		if reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			continue
+		}
+		name := va.Type().Field(i).Name
+		if fa.Kind() == reflect.Slice {
+			for j := 0; j < min(fa.Len(), fb.Len()); j++ {
+				if ea, eb := fa.Index(j).Interface(), fb.Index(j).Interface(); !reflect.DeepEqual(ea, eb) {
+					return fmt.Sprintf("%s[%d]: %+v, want %+v", name, j, eb, ea)
+				}
+			}
+			return fmt.Sprintf("%s: %d entries, want %d", name, fb.Len(), fa.Len())
+		}
+		return fmt.Sprintf("%s: %v, want %v", name, fb.Interface(), fa.Interface())
+	}
+	return ""
+}
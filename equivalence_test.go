@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func Test_equivalence(t *testing.T) {
+	t.Parallel()
+	cycles := path.Join(t.TempDir(), "cycles.csv")
+	if err := os.WriteFile(cycles, []byte(`pid,arrival,priority,bursts
+1,0,3,"cpu:3,io:4,cpu:2,io:1,cpu:5"
+2,1,1,"cpu:1,io:2,cpu:1,io:2,cpu:1"
+3,2,2,cpu:6
+4,9,1,"cpu:2,io:7,cpu:2"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deps := path.Join(t.TempDir(), "deps.csv")
+	if err := os.WriteFile(deps, []byte(`pid,arrival,priority,depends_on,bursts
+1,0,2,,cpu:4
+2,0,1,1,cpu:3
+3,1,1,1,cpu:2
+4,4,3,2,cpu:1
+5,4,0,,"cpu:2,io:2,cpu:1"
+6,9,1,"4 5",cpu:1
+7,2,1,3,"cpu:2,io:3,cpu:1"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		wantLast string
+		wantErr  error
+	}{
+		{
+			name:     "engines agree",
+			args:     []string{"-random", "30", "example_processes.csv"},
+			wantLast: "124 checks, 0 mismatches between the event and tick engines",
+		},
+		{
+			name:     "engines agree with overhead",
+			args:     []string{"-random", "10", "-sched-overhead", "1", "-quantum", "3"},
+			wantLast: "40 checks, 0 mismatches between the event and tick engines",
+		},
+		{
+			name:     "engines agree on CPU and I/O cycles",
+			args:     []string{"-sched-overhead", "1", cycles},
+			wantLast: "4 checks, 0 mismatches between the event and tick engines",
+		},
+		{
+			name:     "engines agree on dependencies",
+			args:     []string{"-quantum", "1", deps},
+			wantLast: "4 checks, 0 mismatches between the event and tick engines",
+		},
+		{
+			name:     "engines agree with arrival ties by PID",
+			args:     []string{"-random", "5", "-seed", "40", "-arrival-ties", "pid", "-algorithms", "rr,sjf"},
+			wantLast: "10 checks, 0 mismatches between the event and tick engines",
+		},
+		{
+			name:    "no workloads",
+			args:    []string{},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown engine",
+			args:    []string{"-engines", "event,quantum", "-random", "1"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := equivalence(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("equivalence() error = %v, want %v\n%s", err, tt.wantErr, w.String())
+			}
+			lines := strings.Split(strings.TrimSpace(w.String()), "\n")
+			if got := lines[len(lines)-1]; got != tt.wantLast {
+				t.Errorf("equivalence() last line = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func Test_referenceRules(t *testing.T) {
+	t.Parallel()
+	for _, s := range schedulers {
+		if _, ok := referenceRules[s.Name]; !ok {
+			t.Errorf("referenceRules has no rule for %s, so the tick engine cannot check it", s.Name)
+		}
+	}
+}
+
+func Test_resultDifference(t *testing.T) {
+	t.Parallel()
+	a := Result{Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 9}}, AveWait: 2}
+	b := Result{Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}}, AveWait: 2}
+	if got, want := resultDifference(a, b), "Gantt[1]: {PID:2 Start:5 Stop:8 Idle:false}, want {PID:2 Start:5 Stop:9 Idle:false}"; got != want {
+		t.Errorf("resultDifference() = %q, want %q", got, want)
+	}
+	b.Gantt, b.AveWait = a.Gantt, 3
+	if got, want := resultDifference(a, b), "AveWait: 3, want 2"; got != want {
+		t.Errorf("resultDifference() = %q, want %q", got, want)
+	}
+	if got := resultDifference(a, a); got != "" {
+		t.Errorf("resultDifference() of equal results = %q, want none", got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// eventLog writes one algorithm's simulation as a chronological log, a
+// line per event, for auditing why the schedule came out as it did:
+// arrivals, every scheduling decision with the policy's reason, I/O
+// waits, completions and idle time.
+type eventLog struct {
+	w       io.Writer
+	nf      numberFormat
+	pending []Process   // not yet logged as arrived, by arrival
+	waking  []TimeSlice // blocked processes not yet logged as woken, by wakeup
+}
+
+// newEventLog starts the log of the algorithm titled title on w.
+func newEventLog(w io.Writer, title string, processes []Process, nf numberFormat) *eventLog {
+	pending := append([]Process(nil), processes...)
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].ArrivalTime < pending[j].ArrivalTime })
+	_, _ = fmt.Fprintf(w, "# %s\n", title)
+	return &eventLog{w: w, nf: nf, pending: pending}
+}
+
+// logf writes an event at time now, after the arrivals and wakeups up to
+// then, which the simulation has no hooks for.
+func (l *eventLog) logf(now int64, format string, args ...any) {
+	for {
+		arrives := len(l.pending) > 0 && l.pending[0].ArrivalTime <= now
+		wakes := len(l.waking) > 0 && l.waking[0].Stop <= now
+		switch {
+		case arrives && (!wakes || l.pending[0].ArrivalTime <= l.waking[0].Stop):
+			p := l.pending[0]
+			l.pending = l.pending[1:]
+			_, _ = fmt.Fprintf(l.w, "t=%s: P%d arrived\n", l.nf.at(p.ArrivalTime), p.ProcessID)
+			continue
+		case wakes:
+			s := l.waking[0]
+			l.waking = l.waking[1:]
+			_, _ = fmt.Fprintf(l.w, "t=%s: P%d finished its I/O\n", l.nf.at(s.Stop), s.PID)
+			continue
+		}
+		break
+	}
+	_, _ = fmt.Fprintf(l.w, "t=%s: %s\n", l.nf.at(now), fmt.Sprintf(format, args...))
+}
+
+func (l *eventLog) observe(d decision) {
+	switch {
+	case d.Previous == nil:
+		l.logf(d.Time, "P%d dispatched [%s]", d.Chosen.ProcessID, d.Reason)
+	case d.Previous == d.Chosen:
+		l.logf(d.Time, "P%d keeps the CPU [%s]", d.Chosen.ProcessID, d.Reason)
+	default:
+		l.logf(d.Time, "P%d preempted by P%d [%s]", d.Previous.ProcessID, d.Chosen.ProcessID, d.Reason)
+	}
+}
+
+// hooks logs the events that are not scheduling decisions.
+func (l *eventLog) hooks() Hooks {
+	return Hooks{
+		OnComplete: func(now, pid int64) { l.logf(now, "P%d completed", pid) },
+		OnBlock: func(now, pid, wake int64) {
+			l.logf(now, "P%d blocked on I/O until t=%s", pid, l.nf.at(wake))
+			i := sort.Search(len(l.waking), func(i int) bool { return l.waking[i].Stop > wake })
+			l.waking = append(l.waking[:i], append([]TimeSlice{{PID: pid, Start: now, Stop: wake}}, l.waking[i:]...)...)
+		},
+		OnIdle: func(from, to int64) { l.logf(from, "CPU idle until t=%s", l.nf.at(to)) },
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_eventLog(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, Bursts: []Burst{{Duration: 2}, {Duration: 3, IO: true}, {Duration: 1}}},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 2, Priority: 2},
+	}
+	var w bytes.Buffer
+	log := newEventLog(&w, "Priority", processes, numberFormat{})
+	simulate(processes, newPriorityPolicy(defaultRunQueue), simOptions{observer: log.observe, hooks: log.hooks()})
+	want := "# Priority\n" +
+		"t=0: P1 arrived\n" +
+		"t=0: P1 dispatched [smallest priority (0)]\n" +
+		"t=1: P2 arrived\n" +
+		"t=1: P1 keeps the CPU [running process still has the smallest priority (0)]\n" +
+		"t=2: P3 arrived\n" +
+		"t=2: P1 blocked on I/O until t=5\n" +
+		"t=2: P2 dispatched [smallest priority (1)]\n" +
+		"t=4: P2 completed\n" +
+		"t=4: P3 dispatched [smallest priority (2)]\n" +
+		"t=5: P1 finished its I/O\n" +
+		"t=5: P3 preempted by P1 [preempts 3, priority 0 < 2]\n" +
+		"t=6: P1 completed\n" +
+		"t=6: P3 dispatched [smallest priority (2)]\n" +
+		"t=7: P3 completed\n"
+	if got := w.String(); got != want {
+		t.Errorf("event log =\n%s\nwant\n%s", got, want)
+	}
+}
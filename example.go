@@ -0,0 +1,57 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// exampleFS holds the textbook workloads the example command runs.
+//
+//go:embed examples/*.csv
+var exampleFS embed.FS
+
+// exampleDescriptions says what each embedded workload demonstrates.
+var exampleDescriptions = map[string]string{
+	"convoy":   "A long batch job ahead of short ones: the convoy effect of first-come, first-serve",
+	"mixed":    "Interactive and batch processes alternating CPU and I/O bursts",
+	"realtime": "Periodic sensor and control tasks with deadlines",
+}
+
+// example runs an embedded workload by name, with the same flags as a
+// regular run. Without a name it lists the examples.
+func example(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"example"}, args...)...)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(exampleDescriptions))
+	for name := range exampleDescriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(args) == 1 {
+		for _, name := range names {
+			_, _ = fmt.Fprintf(w, "%-10s %s\n", name, exampleDescriptions[name])
+		}
+		return nil
+	}
+	if _, ok := exampleDescriptions[args[1]]; len(args) != 2 || !ok {
+		return fmt.Errorf("%w: example takes one of %s", ErrInvalidArgs, strings.Join(names, ", "))
+	}
+
+	name := path.Join("examples", args[1]+".csv")
+	f, err := exampleFS.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	processes, err := loadWorkload(name, f, cfg.loadOptions())
+	if err != nil {
+		return err
+	}
+	return run(w, cfg, processes)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func Test_example(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "list", want: "convoy"},
+		{name: "convoy", args: []string{"--quiet", "--algorithms", "fcfs", "convoy"}, want: "First-come, first-serve: average wait 20.60"},
+		{name: "mixed", args: []string{"--quiet", "mixed"}, want: "Round-robin"},
+		{name: "realtime", args: []string{"--quiet", "realtime"}, want: "Priority"},
+		{name: "unknown", args: []string{"textbook"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := example(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("example() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("example() = %v, want it to contain %q", w.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_exampleDescriptions(t *testing.T) {
+	t.Parallel()
+	files, err := fs.Glob(exampleFS, "examples/*.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(exampleDescriptions) {
+		t.Errorf("%d embedded examples, but %d descriptions", len(files), len(exampleDescriptions))
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "examples/"), ".csv")
+		if _, ok := exampleDescriptions[name]; !ok {
+			t.Errorf("example %s has no description", name)
+		}
+	}
+}
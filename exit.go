@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+)
+
+// Process exit codes, so scripts can tell failures apart.
+const (
+	exitOK = iota
+	// exitUsage reports bad flags, arguments or config.
+	exitUsage
+	// exitInvalidInput reports a workload that could not be parsed or validated.
+	exitInvalidInput
+	// exitSimulation reports a run that failed after its input was accepted.
+	exitSimulation
+	// exitAssertion reports a run that completed but failed a check.
+	exitAssertion
+)
+
+// ErrAssertion marks a completed run whose results failed a check.
+var ErrAssertion = errors.New("assertion failed")
+
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, ErrAssertion):
+		return exitAssertion
+	case errors.Is(err, ErrInvalidWorkload):
+		return exitInvalidInput
+	case errors.Is(err, ErrInvalidArgs), errors.Is(err, ErrInvalidConfig),
+		errors.Is(err, ErrInvalidSweep), errors.Is(err, ErrInvalidVerbosity):
+		return exitUsage
+	default:
+		return exitSimulation
+	}
+}
+
+// fail logs err and exits with the code matching its kind. A request for
+// help has had its usage printed already, so it is not logged.
+func fail(err error) {
+	if !errors.Is(err, flag.ErrHelp) {
+		log.Print(err)
+	}
+	os.Exit(exitCode(err))
+}
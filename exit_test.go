@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func Test_exitCode(t *testing.T) {
+	t.Parallel()
+	_, csvErr := loadProcesses(iotest.ErrReader(io.ErrUnexpectedEOF), loadOptions{})
+	_, _, argsErr := parseArgs("binary_name", "--quantum", "0")
+	_, _, helpErr := parseArgs("binary_name", "--help")
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", want: exitOK},
+		{name: "bad flags", err: argsErr, want: exitUsage},
+		{name: "help", err: helpErr, want: exitOK},
+		{name: "missing file", err: fmt.Errorf("%w: no file", ErrInvalidArgs), want: exitUsage},
+		{name: "unreadable CSV", err: csvErr, want: exitInvalidInput},
+		{name: "invalid workload", err: ErrInvalidWorkload, want: exitInvalidInput},
+		{name: "run failure", err: errors.New("disk full"), want: exitSimulation},
+		{name: "failed check", err: fmt.Errorf("%w: results differ", ErrAssertion), want: exitAssertion},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
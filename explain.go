@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// explainer writes an algorithm's scheduling decisions out as a worked
+// solution: at each one, what was running and ready, with the remaining
+// time and priority the policies key on, and in a sentence why the
+// winner won.
+type explainer struct {
+	w io.Writer
+}
+
+// newExplainer starts the explanation of the algorithm titled title on w.
+func newExplainer(w io.Writer, title string) explainer {
+	_, _ = fmt.Fprintf(w, "How %s decides\n\n", title)
+	return explainer{w: w}
+}
+
+func (e explainer) observe(d decision) {
+	running := "the CPU is free"
+	if d.Previous != nil {
+		running = fmt.Sprintf("P%d is running with %d left", d.Previous.ProcessID, d.Previous.remaining)
+	}
+	ready := "no other process is ready"
+	if len(d.Ready) > 0 {
+		parts := make([]string, len(d.Ready))
+		for i, t := range d.Ready {
+			parts[i] = fmt.Sprintf("P%d (remaining %d, priority %d)", t.ProcessID, t.remaining, t.Priority)
+		}
+		ready = "ready in queue order: " + strings.Join(parts, ", ")
+	}
+	_, _ = fmt.Fprintf(e.w, "t=%d: %s; %s.\n  %s\n\n", d.Time, running, ready, explainDecision(d))
+}
+
+// explainDecision says in a sentence why d went the way it did.
+func explainDecision(d decision) string {
+	chosen, why := d.Chosen.ProcessID, d.Why
+	switch why.kind {
+	case reasonKeepsRunning:
+		return fmt.Sprintf("P%d keeps the CPU: the policy is non-preemptive, so it runs until its burst ends.", chosen)
+	case reasonEarliestArrival:
+		return fmt.Sprintf("P%d runs because it reached the ready queue first.", chosen)
+	case reasonNextInQueue:
+		return fmt.Sprintf("P%d runs because it is at the front of the ready queue.", chosen)
+	case reasonQuantumNoOther:
+		return fmt.Sprintf("P%d's quantum has expired, but with no other process ready it keeps the CPU.", chosen)
+	case reasonQuantumRotates:
+		return fmt.Sprintf("P%d's quantum has expired, so it moves to the back of the queue and P%d, at the front, runs.", why.pid, chosen)
+	case reasonSmallestKey:
+		return fmt.Sprintf("P%d runs because it has the smallest %s, %d.", chosen, why.keyName, why.key)
+	case reasonStillSmallest:
+		return fmt.Sprintf("P%d keeps the CPU because no ready process has a smaller %s than its %d.", chosen, why.keyName, why.key)
+	case reasonPreempts:
+		return fmt.Sprintf("P%d preempts P%d because its %s, %d, is smaller than P%d's %d.", chosen, why.pid, why.keyName, why.key, why.pid, why.other)
+	}
+	return fmt.Sprintf("P%d runs: %s.", chosen, d.Reason)
+}
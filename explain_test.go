@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_explainer(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 1, Priority: 3},
+	}
+	tests := []struct {
+		name  string
+		title string
+		pol   policy
+		want  string
+	}{
+		{
+			name:  "priority",
+			title: "Priority",
+			pol:   newPriorityPolicy(defaultRunQueue),
+			want: "How Priority decides\n\n" +
+				"t=0: the CPU is free; ready in queue order: P1 (remaining 4, priority 2).\n" +
+				"  P1 runs because it has the smallest priority, 2.\n\n" +
+				"t=1: P1 is running with 3 left; ready in queue order: P2 (remaining 1, priority 1), P3 (remaining 2, priority 3).\n" +
+				"  P2 preempts P1 because its priority, 1, is smaller than P1's 2.\n\n" +
+				"t=2: the CPU is free; ready in queue order: P1 (remaining 3, priority 2), P3 (remaining 2, priority 3).\n" +
+				"  P1 runs because it has the smallest priority, 2.\n\n" +
+				"t=5: the CPU is free; ready in queue order: P3 (remaining 2, priority 3).\n" +
+				"  P3 runs because it has the smallest priority, 3.\n\n",
+		},
+		{
+			name:  "round robin",
+			title: "Round-robin",
+			pol:   &rrPolicy{q: 2},
+			want: "How Round-robin decides\n\n" +
+				"t=0: the CPU is free; ready in queue order: P1 (remaining 4, priority 2).\n" +
+				"  P1 runs because it is at the front of the ready queue.\n\n" +
+				"t=2: P1 is running with 2 left; ready in queue order: P2 (remaining 1, priority 1), P3 (remaining 2, priority 3).\n" +
+				"  P1's quantum has expired, so it moves to the back of the queue and P2, at the front, runs.\n\n" +
+				"t=3: the CPU is free; ready in queue order: P3 (remaining 2, priority 3), P1 (remaining 2, priority 2).\n" +
+				"  P3 runs because it is at the front of the ready queue.\n\n" +
+				"t=5: the CPU is free; ready in queue order: P1 (remaining 2, priority 2).\n" +
+				"  P1 runs because it is at the front of the ready queue.\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			simulate(processes, tt.pol, simOptions{observer: newExplainer(&w, tt.title).observe})
+			if got := w.String(); got != tt.want {
+				t.Errorf("explanation =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import "io"
+
+// resultFormat writes a run's results in some format other than the
+// default text, either as the simulations run or once they all have.
+type resultFormat struct {
+	// hooks, when set, returns the hooks that write an algorithm's events
+	// to w as its simulation runs.
+	hooks func(w io.Writer, algorithm string) Hooks
+	// write, when set, writes the whole run's results once every
+	// algorithm has run.
+	write func(w io.Writer, cfg Config, warnings []warning, summary []summaryRow) error
+}
+
+// resultFormats maps the --format values onto their writers. Text, the
+// default, is written algorithm by algorithm as each run finishes, and so
+// has none.
+var resultFormats = map[string]resultFormat{
+	"":            {},
+	"text":        {},
+	"json":        {write: outputJSON},
+	"ndjson":      {hooks: ndjsonHooks},
+	"csv":         {write: outputCSV},
+	"md":          {write: outputMarkdown},
+	"plantuml":    {write: outputPlantUML},
+	"latex":       {write: outputLaTeX},
+	"trace-event": {write: outputTraceEvents},
+	"xlsx":        {write: outputXLSX},
+	"chapters":    {write: outputChapters},
+}
+
+// resultFormat returns how the run's results are written: through the
+// template when there is one, or else in the chosen format.
+func (c Config) resultFormat() resultFormat {
+	if c.Template != "" {
+		return resultFormat{write: outputTemplate}
+	}
+	return resultFormats[c.Format]
+}
+
+// custom reports whether f replaces the text output.
+func (f resultFormat) custom() bool {
+	return f.hooks != nil || f.write != nil
+}
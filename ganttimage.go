@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	ganttImageWidth     = 960 // of the time axis, in pixels
+	ganttLaneHeight     = 28
+	ganttAxisHeight     = 24
+	ganttMinLabelPixels = 48 // between time axis labels
+)
+
+// writeGanttImage renders every algorithm's Gantt chart to a PNG file at
+// path, for slides and documents where SVG is inconvenient.
+func writeGanttImage(path string, rows []summaryRow, opts outputOptions) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating Gantt image", err)
+	}
+	defer f.Close()
+	if err := writeGanttPNG(f, rows, opts); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeGanttPNG draws one lane per algorithm on a shared time axis, each
+// slice filled with its process's palette color and labelled with its
+// name or PID where the label fits. Idle slices are painted in the idle
+// color and labelled IDLE.
+func writeGanttPNG(w io.Writer, rows []summaryRow, opts outputOptions) error {
+	face := basicfont.Face7x13
+	lanes := make([][]TimeSlice, len(rows))
+	labelWidth, start, end := 0, int64(math.MaxInt64), int64(0)
+	for i, row := range rows {
+		lanes[i] = clipGantt(row.Result.Gantt, opts.from, opts.to)
+		labelWidth = max(labelWidth, len(row.Scheduler.Name)*face.Advance+2*summaryCellPadding)
+		for _, s := range lanes[i] {
+			start, end = min(start, s.Start), max(end, s.Stop)
+		}
+	}
+	if end <= start {
+		start, end = 0, 1
+	}
+	scale := float64(ganttImageWidth) / float64(end-start)
+	x := func(t int64) int { return labelWidth + int(math.Round(float64(t-start)*scale)) }
+
+	height := len(rows)*ganttLaneHeight + ganttAxisHeight
+	img := image.NewRGBA(image.Rect(0, 0, labelWidth+ganttImageWidth+summaryCellPadding, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	d := font.Drawer{Dst: img, Src: image.Black, Face: face}
+	for i, row := range rows {
+		y := i * ganttLaneHeight
+		d.Dot = fixed.P(summaryCellPadding, y+ganttLaneHeight-9)
+		d.DrawString(row.Scheduler.Name)
+		names := processNames(row.Result.Schedule)
+		for _, s := range lanes[i] {
+			rect := image.Rect(x(s.Start), y+2, x(s.Stop), y+ganttLaneHeight-2)
+			fill, label := colorForPID(s.PID), names[s.PID]
+			switch {
+			case s.Idle:
+				fill, label = idleColor, ganttIdleLabel
+			case label == "":
+				label = strconv.FormatInt(s.PID, 10)
+			}
+			draw.Draw(img, rect, image.NewUniform(rgbColor(fill.Hex)), image.Point{}, draw.Src)
+			strokeRect(img, rect, summaryGridColor)
+			if width := len(label) * face.Advance; width+4 <= rect.Dx() {
+				d.Dot = fixed.P(rect.Min.X+(rect.Dx()-width)/2, y+ganttLaneHeight-9)
+				d.DrawString(label)
+			}
+		}
+	}
+
+	axis := len(rows) * ganttLaneHeight
+	for px := labelWidth; px <= labelWidth+ganttImageWidth; px++ {
+		img.Set(px, axis, summaryGridColor)
+	}
+	step := int64(math.Ceil(ganttMinLabelPixels / scale))
+	for t := start; t <= end; t += step {
+		for y := axis; y < axis+4; y++ {
+			img.Set(x(t), y, summaryGridColor)
+		}
+		d.Dot = fixed.P(x(t)-face.Advance/2, axis+17)
+		d.DrawString(opts.numbers.time(t))
+	}
+
+	return png.Encode(w, img)
+}
+
+// rgbColor parses a palette color given as "#rrggbb".
+func rgbColor(hex string) color.RGBA {
+	v, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func Test_writeGanttPNG(t *testing.T) {
+	t.Parallel()
+	rows := []summaryRow{{
+		Scheduler: Scheduler{Name: "fcfs"},
+		Result:    Result{Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 4, Stop: 8}}},
+	}}
+	var b bytes.Buffer
+	if err := writeGanttPNG(&b, rows, outputOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "fcfs" is 4 characters of 7 pixels plus padding on both sides, and
+	// 8 ticks span the 960 pixel axis.
+	if got := img.Bounds().Size(); got.X != 52+960+12 || got.Y != 28+24 {
+		t.Fatalf("image size = %v", got)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want color.Color
+	}{
+		{name: "first slice", x: 52 + 60, y: 5, want: rgbColor(colorForPID(1).Hex)},
+		{name: "idle", x: 52 + 420, y: 5, want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{name: "second slice", x: 52 + 540, y: 5, want: rgbColor(colorForPID(2).Hex)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := color.RGBAModel.Convert(img.At(tt.x, tt.y)); got != tt.want {
+				t.Errorf("pixel at (%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// generatorSpec bounds the values of a randomly generated workload.
+type generatorSpec struct {
+	Count       int   `yaml:"count" toml:"count"`
+	MaxArrival  int64 `yaml:"max_arrival" toml:"max_arrival"`
+	MaxBurst    int64 `yaml:"max_burst" toml:"max_burst"`
+	MaxPriority int64 `yaml:"max_priority" toml:"max_priority"`
+	// PIDs names the pidSchemes entry that numbers the processes; empty
+	// means sequential.
+	PIDs string `yaml:"pids" toml:"pids"`
+	// Arrivals, Bursts and Priorities name the distributions values are
+	// drawn from; empty means uniform.
+	Arrivals   string `yaml:"arrivals" toml:"arrivals"`
+	Bursts     string `yaml:"bursts" toml:"bursts"`
+	Priorities string `yaml:"priorities" toml:"priorities"`
+}
+
+// distribution returns a function drawing successive values of one
+// process field from rng, within the spec's bounds.
+type distribution func(rng *rand.Rand, spec generatorSpec) func() int64
+
+// Shape parameters of the skewed distributions.
+const (
+	// paretoAlpha gives a heavy tail: most bursts are short, a few run to
+	// the maximum.
+	paretoAlpha = 1.5
+	// zipfS weights priorities towards 1, the most urgent.
+	zipfS = 1.5
+)
+
+// arrivalDists draw successive arrival times. Poisson arrivals have
+// exponential gaps averaging MaxArrival/Count, so they span about the same
+// window as uniform ones but cluster and may run past it.
+var arrivalDists = map[string]distribution{
+	"uniform": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		return func() int64 { return rng.Int63n(spec.MaxArrival + 1) }
+	},
+	"poisson": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		var (
+			mean = float64(spec.MaxArrival) / float64(spec.Count)
+			now  int64
+		)
+		return func() int64 {
+			now += int64(math.Round(rng.ExpFloat64() * mean))
+			return now
+		}
+	},
+}
+
+// burstDists draw burst durations between 1 and MaxBurst. Both skewed
+// ones average half of MaxBurst before they are clipped to it.
+var burstDists = map[string]distribution{
+	"uniform": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		return func() int64 { return 1 + rng.Int63n(spec.MaxBurst) }
+	},
+	"exponential": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		mean := float64(spec.MaxBurst) / 2
+		return func() int64 { return clampBurst(rng.ExpFloat64()*mean, spec) }
+	},
+	"pareto": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		// The smallest burst, xm, sets the mean: alpha*xm/(alpha-1).
+		xm := float64(spec.MaxBurst) / 2 * (paretoAlpha - 1) / paretoAlpha
+		return func() int64 { return clampBurst(xm*math.Pow(1-rng.Float64(), -1/paretoAlpha), spec) }
+	},
+}
+
+func clampBurst(v float64, spec generatorSpec) int64 {
+	return min(max(int64(math.Round(v)), 1), spec.MaxBurst)
+}
+
+// priorityDists draw priorities between 1 and MaxPriority.
+var priorityDists = map[string]distribution{
+	"uniform": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		return func() int64 { return 1 + rng.Int63n(spec.MaxPriority) }
+	},
+	"zipf": func(rng *rand.Rand, spec generatorSpec) func() int64 {
+		z := rand.NewZipf(rng, zipfS, 1, uint64(spec.MaxPriority-1))
+		return func() int64 { return 1 + int64(z.Uint64()) }
+	},
+}
+
+// lookupDistribution finds name, empty meaning uniform, in dists.
+func lookupDistribution(dists map[string]distribution, name string) (distribution, bool) {
+	if name == "" {
+		name = "uniform"
+	}
+	d, ok := dists[name]
+	return d, ok
+}
+
+// pidSparseGap bounds the gap between consecutive sparse PIDs.
+const pidSparseGap = 16
+
+// pidSchemes assign n distinct process IDs, in arrival order, drawing any
+// randomness from rng so a seeded workload keeps its PIDs.
+var pidSchemes = map[string]func(rng *rand.Rand, n int) []int64{
+	// sequential numbers the processes 1, 2, 3, ...
+	"sequential": func(_ *rand.Rand, n int) []int64 {
+		pids := make([]int64, n)
+		for i := range pids {
+			pids[i] = int64(i + 1)
+		}
+		return pids
+	},
+	// random scatters the PIDs over a pid_max sized space, the way a
+	// long-running system hands them out once they have wrapped around.
+	"random": func(rng *rand.Rand, n int) []int64 {
+		space := max(int64(32768), 4*int64(n))
+		pids := make([]int64, n)
+		seen := make(map[int64]bool, n)
+		for i := range pids {
+			pid := 1 + rng.Int63n(space)
+			for seen[pid] {
+				pid = 1 + rng.Int63n(space)
+			}
+			seen[pid] = true
+			pids[i] = pid
+		}
+		return pids
+	},
+	// sparse keeps the PIDs increasing but leaves random gaps, as when
+	// other processes were started in between.
+	"sparse": func(rng *rand.Rand, n int) []int64 {
+		pids := make([]int64, n)
+		var pid int64
+		for i := range pids {
+			pid += 1 + rng.Int63n(pidSparseGap)
+			pids[i] = pid
+		}
+		return pids
+	},
+}
+
+var pidSchemeNames = []string{"sequential", "random", "sparse"}
+
+func (s generatorSpec) validate() error {
+	switch {
+	case s.Count <= 0:
+		return fmt.Errorf("%w: process count must be positive, got %d", ErrInvalidArgs, s.Count)
+	case s.MaxArrival < 0:
+		return fmt.Errorf("%w: max arrival must not be negative, got %d", ErrInvalidArgs, s.MaxArrival)
+	case s.MaxBurst < 1:
+		return fmt.Errorf("%w: max burst must be at least 1, got %d", ErrInvalidArgs, s.MaxBurst)
+	case s.MaxPriority < 1:
+		return fmt.Errorf("%w: max priority must be at least 1, got %d", ErrInvalidArgs, s.MaxPriority)
+	}
+	if _, ok := pidSchemes[s.PIDs]; s.PIDs != "" && !ok {
+		return fmt.Errorf("%w: unknown PID scheme %q, want one of %s", ErrInvalidArgs, s.PIDs, strings.Join(pidSchemeNames, ", "))
+	}
+	if _, ok := lookupDistribution(arrivalDists, s.Arrivals); !ok {
+		return fmt.Errorf("%w: unknown arrival distribution %q, want uniform or poisson", ErrInvalidArgs, s.Arrivals)
+	}
+	if _, ok := lookupDistribution(burstDists, s.Bursts); !ok {
+		return fmt.Errorf("%w: unknown burst distribution %q, want uniform, exponential or pareto", ErrInvalidArgs, s.Bursts)
+	}
+	if _, ok := lookupDistribution(priorityDists, s.Priorities); !ok {
+		return fmt.Errorf("%w: unknown priority distribution %q, want uniform or zipf", ErrInvalidArgs, s.Priorities)
+	}
+	return nil
+}
+
+// generateProcesses draws a workload from rng with arrivals, bursts and
+// priorities from the spec's distributions, given PIDs in arrival order by
+// its PID scheme. The same seed always yields the same workload.
+func generateProcesses(rng *rand.Rand, spec generatorSpec) []Process {
+	newArrival, _ := lookupDistribution(arrivalDists, spec.Arrivals)
+	newBurst, _ := lookupDistribution(burstDists, spec.Bursts)
+	newPriority, _ := lookupDistribution(priorityDists, spec.Priorities)
+	arrival, burst, priority := newArrival(rng, spec), newBurst(rng, spec), newPriority(rng, spec)
+
+	processes := make([]Process, spec.Count)
+	for i := range processes {
+		processes[i] = Process{
+			ArrivalTime:   arrival(),
+			BurstDuration: burst(),
+			Priority:      priority(),
+		}
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+	scheme := spec.PIDs
+	if scheme == "" {
+		scheme = "sequential"
+	}
+	for i, pid := range pidSchemes[scheme](rng, len(processes)) {
+		processes[i].ProcessID = pid
+	}
+	return processes
+}
+
+// writeProcesses writes processes in the workload CSV layout that
+// loadProcesses reads back. The deadline column is only written when some
+// process has a deadline, and the bursts, name and affinity columns, under
+// a header row, when some process has one. Weights go in a nice column when
+// every process's nice value accounts for its weight and priority, and in
+// a weight column otherwise.
+func writeProcesses(w io.Writer, processes []Process) error {
+	var deadlines, bursts, names, owners, affinities, deps bool
+	// Nice and weight columns need a value in every row, so they are
+	// written only when every process was given one; their priorities
+	// carry the nice values otherwise.
+	nices, weights := len(processes) > 0, len(processes) > 0
+	for _, p := range processes {
+		weights = weights && p.Weight != 0
+		nices = nices && p.Nice >= minNice && p.Nice <= maxNice &&
+			p.Weight == niceWeights[p.Nice-minNice] && p.Priority == p.Nice-minNice
+		deadlines = deadlines || p.Deadline > 0
+		bursts = bursts || p.Bursts != nil
+		names = names || p.Name != ""
+		owners = owners || p.Owner != ""
+		deps = deps || p.DependsOn != nil
+		affinities = affinities || p.Affinity != 0
+	}
+	cw := csv.NewWriter(w)
+	weights = weights && !nices
+	if bursts || names || owners || affinities || deps || nices || weights {
+		header := []string{"pid", "burst", "arrival", "priority"}
+		if deadlines {
+			header = append(header, "deadline")
+		}
+		if bursts {
+			header = append(header, "bursts")
+		}
+		if names {
+			header = append(header, "name")
+		}
+		if owners {
+			header = append(header, "owner")
+		}
+		if affinities {
+			header = append(header, "affinity")
+		}
+		if deps {
+			header = append(header, "depends_on")
+		}
+		if nices {
+			header = append(header, "nice")
+		}
+		if weights {
+			header = append(header, "weight")
+		}
+		_ = cw.Write(header)
+	}
+	for _, p := range processes {
+		record := []string{
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		}
+		if deadlines {
+			record = append(record, strconv.FormatInt(p.Deadline, 10))
+		}
+		if bursts {
+			record = append(record, formatBursts(p.Bursts))
+		}
+		if names {
+			record = append(record, p.Name)
+		}
+		if owners {
+			record = append(record, p.Owner)
+		}
+		if affinities {
+			record = append(record, formatAffinity(p.Affinity))
+		}
+		if deps {
+			record = append(record, formatDependencies(p.DependsOn))
+		}
+		if nices {
+			record = append(record, strconv.FormatInt(p.Nice, 10))
+		}
+		if weights {
+			record = append(record, strconv.FormatInt(p.Weight, 10))
+		}
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// generate writes a random workload CSV. Without --seed a seed is taken
+// from the clock and reported on stderr so the workload can be recreated.
+// With --repeat it instead aggregates every algorithm's metrics over that
+// many workloads drawn from consecutive seeds.
+func generate(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var (
+		spec   generatorSpec
+		seed   = fs.Int64("seed", 0, "random seed; 0 picks one and reports it")
+		repeat = fs.Int("repeat", 0, "simulate this many workloads and report mean and standard deviation")
+	)
+	fs.IntVar(&spec.Count, "n", 10, "number of processes")
+	fs.Int64Var(&spec.MaxArrival, "max-arrival", 20, "latest arrival time")
+	fs.Int64Var(&spec.MaxBurst, "max-burst", 10, "longest burst duration")
+	fs.Int64Var(&spec.MaxPriority, "max-priority", 50, "largest (least urgent) priority value")
+	fs.StringVar(&spec.PIDs, "pids", "sequential", "PID assignment: sequential, random or sparse")
+	fs.StringVar(&spec.Arrivals, "arrivals", "uniform", "arrival time distribution: uniform or poisson")
+	fs.StringVar(&spec.Bursts, "bursts", "uniform", "burst duration distribution: uniform, exponential or pareto")
+	fs.StringVar(&spec.Priorities, "priorities", "uniform", "priority distribution: uniform or zipf")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("%w: generate takes no positional arguments", ErrInvalidArgs)
+	}
+	if err := spec.validate(); err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+		_, _ = fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+	}
+	if *repeat != 0 {
+		return runRepeated(w, spec, *seed, *repeat)
+	}
+	return writeProcesses(w, generateProcesses(rand.New(rand.NewSource(*seed)), spec))
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantLen int
+		wantErr error
+	}{
+		{
+			name:    "seeded",
+			args:    []string{"-seed", "42", "-n", "25", "-max-arrival", "5", "-max-burst", "3", "-max-priority", "4"},
+			wantLen: 25,
+		},
+		{
+			name:    "bad count",
+			args:    []string{"-seed", "42", "-n", "0"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown PID scheme",
+			args:    []string{"-seed", "42", "-pids", "odd"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown distribution",
+			args:    []string{"-seed", "42", "-bursts", "normal"},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unexpected argument",
+			args:    []string{"-seed", "42", "out.csv"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var first, second bytes.Buffer
+			err := generate(&first, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("generate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := generate(&second, tt.args...); err != nil {
+				t.Fatal(err)
+			}
+			if first.String() != second.String() {
+				t.Errorf("generate() with the same seed differs:\n%s\n%s", first.String(), second.String())
+			}
+
+			processes, err := loadProcesses(&first, loadOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(processes) != tt.wantLen {
+				t.Fatalf("generate() wrote %d processes, want %d", len(processes), tt.wantLen)
+			}
+			for i, p := range processes {
+				if p.ProcessID != int64(i+1) || p.ArrivalTime > 5 || p.BurstDuration < 1 || p.BurstDuration > 3 ||
+					p.Priority < 1 || p.Priority > 4 {
+					t.Errorf("generate() process %v out of bounds", p)
+				}
+				if i > 0 && processes[i-1].ArrivalTime > p.ArrivalTime {
+					t.Errorf("generate() processes not in arrival order: %v", processes)
+				}
+			}
+		})
+	}
+}
+
+func Test_generateProcesses_pids(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		scheme     string
+		increasing bool
+	}{
+		{name: "sequential", scheme: "sequential", increasing: true},
+		{name: "random", scheme: "random"},
+		{name: "sparse", scheme: "sparse", increasing: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := generatorSpec{Count: 200, MaxArrival: 50, MaxBurst: 5, MaxPriority: 5, PIDs: tt.scheme}
+			processes := generateProcesses(rand.New(rand.NewSource(7)), spec)
+			again := generateProcesses(rand.New(rand.NewSource(7)), spec)
+			if !reflect.DeepEqual(processes, again) {
+				t.Fatalf("generateProcesses() with the same seed differs")
+			}
+			seen := make(map[int64]bool)
+			for i, p := range processes {
+				if p.ProcessID < 1 || seen[p.ProcessID] {
+					t.Fatalf("generateProcesses() PID %d invalid or repeated", p.ProcessID)
+				}
+				seen[p.ProcessID] = true
+				if tt.increasing && i > 0 && processes[i-1].ProcessID >= p.ProcessID {
+					t.Errorf("generateProcesses() PIDs not increasing: %d then %d", processes[i-1].ProcessID, p.ProcessID)
+				}
+			}
+			var w bytes.Buffer
+			if err := writeProcesses(&w, processes); err != nil {
+				t.Fatal(err)
+			}
+			if _, problems := parseWorkload(&w, loadOptions{}); len(problems) != 0 {
+				t.Errorf("generated workload does not validate: %v", problems)
+			}
+		})
+	}
+}
+
+func Test_generateProcesses_distributions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		spec generatorSpec
+		// check inspects the workload as a whole.
+		check func(t *testing.T, processes []Process)
+	}{
+		{
+			name: "poisson arrivals",
+			spec: generatorSpec{Arrivals: "poisson"},
+			check: func(t *testing.T, processes []Process) {
+				last := processes[len(processes)-1].ArrivalTime
+				if last < 100 || last > 300 {
+					t.Errorf("last poisson arrival = %d, want about 200", last)
+				}
+			},
+		},
+		{
+			name: "exponential bursts",
+			spec: generatorSpec{Bursts: "exponential"},
+			check: func(t *testing.T, processes []Process) {
+				var total int64
+				for _, p := range processes {
+					total += p.BurstDuration
+				}
+				if mean := float64(total) / float64(len(processes)); mean < 3 || mean > 6 {
+					t.Errorf("mean exponential burst = %.2f, want about 4.5", mean)
+				}
+			},
+		},
+		{
+			name: "pareto bursts",
+			spec: generatorSpec{Bursts: "pareto"},
+			check: func(t *testing.T, processes []Process) {
+				short, longest := 0, 0
+				for _, p := range processes {
+					if p.BurstDuration < 5 {
+						short++
+					}
+					if p.BurstDuration == 10 {
+						longest++
+					}
+				}
+				if short < len(processes)/2 || longest == 0 {
+					t.Errorf("%d of %d pareto bursts are short and %d the longest, want most and some", short, len(processes), longest)
+				}
+			},
+		},
+		{
+			name: "zipf priorities",
+			spec: generatorSpec{Priorities: "zipf"},
+			check: func(t *testing.T, processes []Process) {
+				counts := make(map[int64]int)
+				for _, p := range processes {
+					counts[p.Priority]++
+				}
+				if counts[1] <= counts[2] || counts[2] <= counts[5] {
+					t.Errorf("zipf priority counts = %v, want falling from 1", counts)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := tt.spec
+			spec.Count, spec.MaxArrival, spec.MaxBurst, spec.MaxPriority = 200, 200, 10, 5
+			if err := spec.validate(); err != nil {
+				t.Fatal(err)
+			}
+			processes := generateProcesses(rand.New(rand.NewSource(11)), spec)
+			for i, p := range processes {
+				if p.BurstDuration < 1 || p.BurstDuration > 10 || p.Priority < 1 || p.Priority > 5 || p.ArrivalTime < 0 {
+					t.Fatalf("generateProcesses() process %v out of bounds", p)
+				}
+				if i > 0 && processes[i-1].ArrivalTime > p.ArrivalTime {
+					t.Fatalf("generateProcesses() processes not in arrival order")
+				}
+			}
+			tt.check(t, processes)
+		})
+	}
+}
+
+func Test_burstDists_scale(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"exponential", "pareto"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			spec := generatorSpec{MaxBurst: 1000}
+			draw := burstDists[name](rand.New(rand.NewSource(11)), spec)
+			var total int64
+			for i := 0; i < 1000; i++ {
+				total += draw()
+			}
+			if mean := float64(total) / 1000; mean < 250 || mean > 500 {
+				t.Errorf("mean %s burst = %.2f with a maximum of 1000, want about 350-400", name, mean)
+			}
+		})
+	}
+}
+
+func Test_writeProcesses(t *testing.T) {
+	t.Parallel()
+	want := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var w bytes.Buffer
+	if err := writeProcesses(&w, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadProcesses(&w, loadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("writeProcesses() round trip = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidGrid = errors.New("invalid grid")
+
+// gridAxis is one parameter a grid varies, and the values it takes.
+type gridAxis struct {
+	Param  string
+	Values []int64
+}
+
+// parseGrid checks a grid section, such as
+//
+//	[grid]
+//	quantum = [1, 2, 4]
+//	sched_overhead = [0, 1]
+//
+// and returns its axes in name order. Any sweepable parameter can be an
+// axis. The selected algorithms make up the remaining dimension.
+func parseGrid(grid map[string][]int64) ([]gridAxis, error) {
+	axes := make([]gridAxis, 0, len(grid))
+	for param, values := range grid {
+		if param == "cpus" {
+			return nil, fmt.Errorf("%w: cpus cannot vary, the simulator runs %d CPU", ErrInvalidGrid, simulatedCPUs)
+		}
+		if _, ok := sweepParams[param]; !ok {
+			return nil, fmt.Errorf("%w: %q is not a sweepable parameter", ErrInvalidGrid, param)
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: %s has no values", ErrInvalidGrid, param)
+		}
+		axes = append(axes, gridAxis{Param: param, Values: values})
+	}
+	sort.Slice(axes, func(i, j int) bool { return axes[i].Param < axes[j].Param })
+	return axes, nil
+}
+
+// gridPoints expands axes into every combination of their values, the
+// last axis varying fastest.
+func gridPoints(axes []gridAxis) [][]int64 {
+	points := [][]int64{{}}
+	for _, axis := range axes {
+		next := make([][]int64, 0, len(points)*len(axis.Values))
+		for _, point := range points {
+			for _, v := range axis.Values {
+				next = append(next, append(point[:len(point):len(point)], v))
+			}
+		}
+		points = next
+	}
+	return points
+}
+
+// gridMetrics are the averages reported for each algorithm at each point.
+var gridMetrics = []struct {
+	Name  string
+	Value func(r Result) float64
+}{
+	{"avg wait", func(r Result) float64 { return r.AveWait }},
+	{"avg turnaround", func(r Result) float64 { return r.AveTurnaround }},
+	{"avg response", func(r Result) float64 { return r.AveResponse }},
+	{"context switches", func(r Result) float64 { return float64(r.ContextSwitches) }},
+}
+
+// runGrid runs every selected algorithm at every point of the configured
+// grid, spreading the runs over a pool of one worker per CPU, and writes
+// a single CSV with one row per point and, for each algorithm, a column
+// per metric.
+func runGrid(w io.Writer, cfg Config, processes []Process) error {
+	axes, err := parseGrid(cfg.Grid)
+	if err != nil {
+		return err
+	}
+	points := gridPoints(axes)
+	configs := make([]Config, len(points))
+	for i, point := range points {
+		configs[i] = cfg
+		for j, axis := range axes {
+			sweepParams[axis.Param](&configs[i], point[j])
+		}
+		if err := configs[i].validate(); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidGrid, formatGridPoint(axes, point), err)
+		}
+	}
+
+	var (
+		algorithms = len(cfg.Algorithms)
+		results    = make([]Result, len(points)*algorithms)
+		jobs       = make(chan int)
+		wg         sync.WaitGroup
+	)
+	for i := 0; i < min(runtime.GOMAXPROCS(0), len(results)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				c := configs[job/algorithms]
+				s, _ := lookupScheduler(c.Algorithms[job%algorithms])
+				results[job] = s.Schedule(processes, c)
+			}
+		}()
+	}
+	for job := range results {
+		jobs <- job
+	}
+	close(jobs)
+	wg.Wait()
+
+	header := make([]string, 0, len(axes)+algorithms*len(gridMetrics))
+	for _, axis := range axes {
+		header = append(header, axis.Param)
+	}
+	for _, name := range cfg.Algorithms {
+		for _, m := range gridMetrics {
+			header = append(header, name+" "+m.Name)
+		}
+	}
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for i, point := range points {
+		record := make([]string, 0, len(header))
+		for _, v := range point {
+			record = append(record, strconv.FormatInt(v, 10))
+		}
+		for _, r := range results[i*algorithms : (i+1)*algorithms] {
+			for _, m := range gridMetrics {
+				record = append(record, strconv.FormatFloat(m.Value(r), 'f', -1, 64))
+			}
+		}
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatGridPoint(axes []gridAxis, point []int64) string {
+	parts := make([]string, len(axes))
+	for i, axis := range axes {
+		parts[i] = fmt.Sprintf("%s=%d", axis.Param, point[i])
+	}
+	return strings.Join(parts, ", ")
+}
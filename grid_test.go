@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_parseGrid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		grid    map[string][]int64
+		want    []gridAxis
+		wantErr error
+	}{
+		{
+			name: "axes in name order",
+			grid: map[string][]int64{"sched_overhead": {0, 1}, "quantum": {2}},
+			want: []gridAxis{{Param: "quantum", Values: []int64{2}}, {Param: "sched_overhead", Values: []int64{0, 1}}},
+		},
+		{name: "unknown parameter", grid: map[string][]int64{"aging": {1}}, wantErr: ErrInvalidGrid},
+		{name: "cpus", grid: map[string][]int64{"cpus": {1, 2}}, wantErr: ErrInvalidGrid},
+		{name: "no values", grid: map[string][]int64{"quantum": {}}, wantErr: ErrInvalidGrid},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseGrid(tt.grid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseGrid() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_gridPoints(t *testing.T) {
+	t.Parallel()
+	got := gridPoints([]gridAxis{{Param: "quantum", Values: []int64{1, 2}}, {Param: "sched_overhead", Values: []int64{0, 3}}})
+	want := [][]int64{{1, 0}, {1, 3}, {2, 0}, {2, 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gridPoints() = %v, want %v", got, want)
+	}
+}
+
+func Test_runGrid(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	cfg := defaultConfig()
+	cfg.Grid = map[string][]int64{"quantum": {1, 2, 3}, "sched_overhead": {0, 1}}
+
+	var w bytes.Buffer
+	if err := runGrid(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&w).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1+3*2 {
+		t.Fatalf("runGrid() wrote %d rows, want a header and 6 points", len(rows))
+	}
+	// Every cell must match a run on its own, however the pool ordered them.
+	for _, row := range rows[1:] {
+		c := cfg
+		c.Quantum, _ = strconv.ParseInt(row[0], 10, 64)
+		c.SchedOverhead, _ = strconv.ParseInt(row[1], 10, 64)
+		for i, name := range cfg.Algorithms {
+			s, _ := lookupScheduler(name)
+			r := s.Schedule(processes, c)
+			for j, m := range gridMetrics {
+				want := strconv.FormatFloat(m.Value(r), 'f', -1, 64)
+				if got := row[2+i*len(gridMetrics)+j]; got != want {
+					t.Errorf("%s %s at %v = %s, want %s", name, m.Name, row[:2], got, want)
+				}
+			}
+		}
+	}
+}
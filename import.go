@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// importFormats maps the trace formats the import command reads onto
+// their parsers, which return each traced task as a process with times in
+// ticks of length tick.
+var importFormats = map[string]func(r io.Reader, tick time.Duration) ([]Process, error){
+	"perf-sched":  parsePerfSched,
+	"trace-event": parseTraceEvents,
+}
+
+var importFormatNames = []string{"perf-sched", "trace-event"}
+
+// roundTicks converts d to the nearest whole number of ticks.
+func roundTicks(d, tick time.Duration) int64 {
+	return int64(math.Round(float64(d) / float64(tick)))
+}
+
+// importedProcesses orders traced processes the way a workload lists
+// them, by arrival and then PID, with the earliest arrival moved to time
+// zero.
+func importedProcesses(processes []Process) []Process {
+	sort.Slice(processes, func(i, j int) bool {
+		if processes[i].ArrivalTime != processes[j].ArrivalTime {
+			return processes[i].ArrivalTime < processes[j].ArrivalTime
+		}
+		return processes[i].ProcessID < processes[j].ProcessID
+	})
+	if len(processes) > 0 {
+		start := processes[0].ArrivalTime
+		for i := range processes {
+			processes[i].ArrivalTime -= start
+		}
+	}
+	return processes
+}
+
+// importTrace converts a trace recorded on a real machine into a workload
+// CSV, so it can be replayed under other algorithms.
+func importTrace(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var (
+		format = fs.String("format", "perf-sched", "trace format: "+strings.Join(importFormatNames, ", "))
+		tick   = fs.Duration("tick", time.Millisecond, "wall-clock length of a tick, the resolution of the workload")
+	)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	parse, ok := importFormats[*format]
+	if !ok {
+		return fmt.Errorf("%w: unknown trace format %q, want %s", ErrInvalidArgs, *format, strings.Join(importFormatNames, " or "))
+	}
+	if *tick <= 0 {
+		return fmt.Errorf("%w: tick must be positive, got %v", ErrInvalidArgs, *tick)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: import takes one trace file", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%w: %v: error opening trace file", ErrInvalidArgs, err)
+	}
+	defer f.Close()
+	processes, err := parse(f, *tick)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidWorkload, f.Name(), err)
+	}
+	if len(processes) == 0 {
+		return fmt.Errorf("%w: %s: no tasks ran in the trace", ErrInvalidWorkload, f.Name())
+	}
+	return writeProcesses(w, processes)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+)
+
+// checkInvariants verifies that r is a valid schedule of processes, which
+// must have distinct IDs: slices are ordered and never overlap, no process
+// runs before it arrives, before its dependencies complete or for longer
+// or shorter than its burst, and the schedule table agrees with the Gantt
+// chart. The first violation found is returned as an ErrAssertion.
+func checkInvariants(processes []Process, r Result) error {
+	byPID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byPID[p.ProcessID] = p
+	}
+
+	var (
+		ran      = make(map[int64]int64, len(processes))
+		firstRun = make(map[int64]int64, len(processes))
+		lastStop = make(map[int64]int64, len(processes))
+		prev     int64
+	)
+	for i, s := range r.Gantt {
+		if s.Idle {
+			if s.Start < prev {
+				return fmt.Errorf("%w: idle slice %d starts at %d, before the previous slice ends at %d", ErrAssertion, i, s.Start, prev)
+			}
+			prev = s.Stop
+			continue
+		}
+		p, ok := byPID[s.PID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: slice %d runs unknown process %d", ErrAssertion, i, s.PID)
+		case s.Stop < s.Start || (s.Stop == s.Start && p.BurstDuration > 0):
+			// A process with no burst is dispatched and completes at once,
+			// in a slice of its own that takes no time.
+			return fmt.Errorf("%w: slice %d of process %d is empty or reversed (%d..%d)", ErrAssertion, i, s.PID, s.Start, s.Stop)
+		case s.Start < prev:
+			return fmt.Errorf("%w: slice %d of process %d starts at %d, before the previous slice ends at %d", ErrAssertion, i, s.PID, s.Start, prev)
+		case s.Start < p.ArrivalTime:
+			return fmt.Errorf("%w: process %d runs at %d, before it arrives at %d", ErrAssertion, s.PID, s.Start, p.ArrivalTime)
+		}
+		if _, ok := firstRun[s.PID]; !ok {
+			firstRun[s.PID] = s.Start
+		}
+		ran[s.PID] += s.Stop - s.Start
+		lastStop[s.PID] = s.Stop
+		prev = s.Stop
+	}
+
+	if len(r.Schedule) != len(processes) {
+		return fmt.Errorf("%w: schedule has %d rows for %d processes", ErrAssertion, len(r.Schedule), len(processes))
+	}
+	for _, row := range r.Schedule {
+		p, ok := byPID[row.ProcessID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: schedule lists unknown process %d", ErrAssertion, row.ProcessID)
+		case ran[p.ProcessID] != p.BurstDuration:
+			return fmt.Errorf("%w: process %d ran for %d, want its burst of %d", ErrAssertion, p.ProcessID, ran[p.ProcessID], p.BurstDuration)
+		case row.Exit != lastStop[p.ProcessID]:
+			return fmt.Errorf("%w: process %d exits at %d, but its last slice ends at %d", ErrAssertion, p.ProcessID, row.Exit, lastStop[p.ProcessID])
+		case row.Turnaround != row.Exit-p.ArrivalTime || row.Wait != row.Turnaround-p.BurstDuration-row.IO:
+			return fmt.Errorf("%w: process %d has turnaround %d and wait %d, inconsistent with arrival %d, burst %d, I/O %d and exit %d",
+				ErrAssertion, p.ProcessID, row.Turnaround, row.Wait, p.ArrivalTime, p.BurstDuration, row.IO, row.Exit)
+		}
+	}
+	for _, p := range processes {
+		for _, dep := range p.DependsOn {
+			if _, known := byPID[dep]; known && firstRun[p.ProcessID] < lastStop[dep] {
+				return fmt.Errorf("%w: process %d runs at %d, before process %d it depends on completes at %d",
+					ErrAssertion, p.ProcessID, firstRun[p.ProcessID], dep, lastStop[dep])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func Test_checkInvariants(t *testing.T) {
+	t.Parallel()
+	processes := generateProcesses(rand.New(rand.NewSource(5)), generatorSpec{Count: 50, MaxArrival: 100, MaxBurst: 8, MaxPriority: 5})
+	cfg := defaultConfig()
+	for _, s := range schedulers {
+		if err := checkInvariants(processes, s.Schedule(processes, cfg)); err != nil {
+			t.Errorf("checkInvariants() on %s = %v, want nil", s.Name, err)
+		}
+	}
+
+	zeroBurst := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 0},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 0, DependsOn: []int64{2}},
+	}
+	for _, s := range schedulers {
+		if err := checkInvariants(zeroBurst, s.Schedule(zeroBurst, cfg)); err != nil {
+			t.Errorf("checkInvariants() with zero bursts on %s = %v, want nil", s.Name, err)
+		}
+	}
+
+	example := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	tests := []struct {
+		name    string
+		corrupt func(r *Result)
+	}{
+		{name: "runs before arrival", corrupt: func(r *Result) { r.Gantt[1].Start, r.Gantt[1].Stop = 2, 11 }},
+		{name: "overlapping slices", corrupt: func(r *Result) { r.Gantt[1].Start = 4 }},
+		{name: "empty slice", corrupt: func(r *Result) { r.Gantt[1].Stop = r.Gantt[1].Start }},
+		{name: "short burst", corrupt: func(r *Result) { r.Gantt[1].Stop-- }},
+		{name: "wrong exit", corrupt: func(r *Result) { r.Schedule[0].Exit++ }},
+		{name: "missing row", corrupt: func(r *Result) { r.Schedule = r.Schedule[:1] }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(example, &fcfsPolicy{}, simOptions{})
+			tt.corrupt(&r)
+			if err := checkInvariants(example, r); !errors.Is(err, ErrAssertion) {
+				t.Errorf("checkInvariants() = %v, want %v", err, ErrAssertion)
+			}
+		})
+	}
+}
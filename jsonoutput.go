@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonVersion heads --format json output, and changes only when the
+// document's layout does.
+const jsonVersion = 1
+
+// jsonRun is the document --format json writes: every algorithm's full
+// result, for scripts and notebooks. Times are in raw ticks,
+// TicksPerUnit of them to a time unit.
+type jsonRun struct {
+	Version      int             `json:"version"`
+	TicksPerUnit int64           `json:"ticks_per_unit"`
+	Warnings     []warning       `json:"warnings"`
+	Algorithms   []jsonAlgorithm `json:"algorithms"`
+}
+
+type jsonAlgorithm struct {
+	Name      string        `json:"name"`
+	Title     string        `json:"title"`
+	Processes []jsonProcess `json:"processes"`
+	Gantt     []jsonSlice   `json:"gantt"`
+	Metrics   jsonMetrics   `json:"metrics"`
+}
+
+type jsonProcess struct {
+	PID        int64  `json:"pid"`
+	Name       string `json:"name,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+	Priority   int64  `json:"priority"`
+	Burst      int64  `json:"burst"`
+	Arrival    int64  `json:"arrival"`
+	Wait       int64  `json:"wait"`
+	Turnaround int64  `json:"turnaround"`
+	Response   int64  `json:"response"`
+	Completion int64  `json:"completion"`
+	IO         int64  `json:"io,omitempty"`
+	Deadline   int64  `json:"deadline,omitempty"`
+	MissedBy   int64  `json:"missed_by,omitempty"`
+}
+
+type jsonSlice struct {
+	PID   int64 `json:"pid"`
+	Start int64 `json:"start"`
+	Stop  int64 `json:"stop"`
+	Idle  bool  `json:"idle,omitempty"`
+}
+
+type jsonMetrics struct {
+	AvgWait         float64            `json:"avg_wait"`
+	AvgTurnaround   float64            `json:"avg_turnaround"`
+	AvgResponse     float64            `json:"avg_response"`
+	Throughput      float64            `json:"throughput"`
+	Decisions       int                `json:"decisions"`
+	ContextSwitches int                `json:"context_switches"`
+	Busy            int64              `json:"busy"`
+	Overhead        int64              `json:"overhead"`
+	Idle            int64              `json:"idle"`
+	Makespan        int64              `json:"makespan"`
+	Custom          map[string]float64 `json:"custom,omitempty"`
+}
+
+// outputJSON writes a run's results as one indented JSON document.
+func outputJSON(w io.Writer, cfg Config, warnings []warning, summary []summaryRow) error {
+	doc := jsonRun{
+		Version:      jsonVersion,
+		TicksPerUnit: cfg.ticksPerUnit(),
+		Warnings:     warnings,
+		Algorithms:   make([]jsonAlgorithm, 0, len(summary)),
+	}
+	if doc.Warnings == nil {
+		doc.Warnings = []warning{}
+	}
+	for _, row := range summary {
+		r := row.Result
+		a := jsonAlgorithm{
+			Name:      row.Scheduler.Name,
+			Title:     row.Scheduler.Title,
+			Processes: make([]jsonProcess, 0, len(r.Schedule)),
+			Gantt:     make([]jsonSlice, 0, len(r.Gantt)),
+			Metrics: jsonMetrics{
+				AvgWait:         r.AveWait,
+				AvgTurnaround:   r.AveTurnaround,
+				AvgResponse:     r.AveResponse,
+				Throughput:      r.AveThroughput,
+				Decisions:       r.Decisions,
+				ContextSwitches: r.ContextSwitches,
+				Busy:            r.Busy,
+				Overhead:        r.Overhead,
+				Idle:            r.Idle,
+				Makespan:        r.Makespan,
+				Custom:          r.Metrics,
+			},
+		}
+		for _, p := range r.Schedule {
+			a.Processes = append(a.Processes, jsonProcess{
+				PID: p.ProcessID, Name: p.Name, Owner: p.Owner, Priority: p.Priority, Burst: p.Burst, Arrival: p.Arrival,
+				Wait: p.Wait, Turnaround: p.Turnaround, Response: p.Response, Completion: p.Exit,
+				IO: p.IO, Deadline: p.Deadline, MissedBy: p.MissedBy,
+			})
+		}
+		for _, s := range r.Gantt {
+			a.Gantt = append(a.Gantt, jsonSlice{PID: s.PID, Start: s.Start, Stop: s.Stop, Idle: s.Idle})
+		}
+		doc.Algorithms = append(doc.Algorithms, a)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_outputJSON(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Priority: 1},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs", "priority"}
+	cfg.Format = "json"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+
+	var got jsonRun
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, w.String())
+	}
+	if got.Version != jsonVersion || got.TicksPerUnit != 1 || len(got.Algorithms) != 2 {
+		t.Fatalf("outputJSON() = %+v, want version %d, 1 tick per unit and 2 algorithms", got, jsonVersion)
+	}
+	if want := []warning{{warnNoAging, "priority scheduling has no aging, so processes of lower priority can starve"}}; !reflect.DeepEqual(got.Warnings, want) {
+		t.Errorf("outputJSON() warnings = %v, want %v", got.Warnings, want)
+	}
+	fcfs := got.Algorithms[0]
+	wantProcesses := []jsonProcess{
+		{PID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 0, Turnaround: 5, Response: 0, Completion: 5},
+		{PID: 2, Priority: 1, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Response: 4, Completion: 8},
+	}
+	if fcfs.Name != "fcfs" || !reflect.DeepEqual(fcfs.Processes, wantProcesses) {
+		t.Errorf("outputJSON() fcfs = %+v, want processes %+v", fcfs, wantProcesses)
+	}
+	if want := []jsonSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}}; !reflect.DeepEqual(fcfs.Gantt, want) {
+		t.Errorf("outputJSON() fcfs gantt = %v, want %v", fcfs.Gantt, want)
+	}
+	if m := fcfs.Metrics; m.AvgWait != 2 || m.AvgTurnaround != 6 || m.Makespan != 8 || m.ContextSwitches != 1 {
+		t.Errorf("outputJSON() fcfs metrics = %+v, want avg wait 2, avg turnaround 6, makespan 8, 1 context switch", m)
+	}
+}
+
+func Test_outputJSON_zeroBursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1}, {ProcessID: 2}}
+	cfg := defaultConfig()
+	cfg.Format = "json"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatalf("run() of processes that all complete at time 0 error = %v", err)
+	}
+	var got jsonRun
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, w.String())
+	}
+	for _, a := range got.Algorithms {
+		if a.Metrics.Throughput != 0 {
+			t.Errorf("outputJSON() %s throughput = %v, want 0", a.Name, a.Metrics.Throughput)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// latexGanttWidth is the width, in centimetres, a TikZ Gantt chart is
+// scaled to.
+const latexGanttWidth = 14.0
+
+// outputLaTeX writes a run's results as a LaTeX fragment for \input into
+// course notes, needing the booktabs and tikz packages: per algorithm a
+// section with a TikZ Gantt chart and the schedule table, its averages in
+// a closing row, then a comparison table when more than one algorithm ran.
+func outputLaTeX(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	opts := cfg.outputOptions()
+	nf := opts.numbers
+	var b strings.Builder
+	b.WriteString("% Requires \\usepackage{booktabs} and \\usepackage{tikz}.\n")
+
+	for _, row := range summary {
+		r := row.Result
+		fmt.Fprintf(&b, "\n\\section*{%s}\n\n", latexEscape(row.Scheduler.Title))
+		writeTikZGantt(&b, clipGantt(r.Gantt, opts.from, opts.to), processNames(r.Schedule), nf)
+
+		columns := opts.columns
+		if columns == nil {
+			columns = defaultColumnsFor(r.Schedule)
+		}
+		rows := opts.sortBy.sort(r.Schedule)
+		if opts.maxRows > 0 && len(rows) > opts.maxRows {
+			rows = rows[:opts.maxRows]
+		}
+		header := make([]string, len(columns))
+		averages := make([]string, len(columns))
+		for j, c := range columns {
+			header[j] = nf.header(c.Header)
+			if c.Average != nil {
+				averages[j] = nf.duration(c.Average(r))
+			}
+		}
+		if averages[0] == "" {
+			averages[0] = nf.header("Average")
+		}
+		cells := [][]string{header}
+		for _, p := range rows {
+			line := make([]string, len(columns))
+			for j, c := range columns {
+				line[j] = c.format(p, nf)
+			}
+			cells = append(cells, line)
+		}
+		b.WriteString("\n")
+		writeLaTeXTable(&b, cells, averages, nil)
+		fmt.Fprintf(&b, "\n%s: %s\n", latexEscape(nf.header("Throughput")), latexEscape(nf.rate(r.AveThroughput)))
+		for _, line := range customMetricLines(r, metricPlugins, nf) {
+			fmt.Fprintf(&b, "\n%s\n", latexEscape(line))
+		}
+	}
+
+	if len(summary) > 1 {
+		cells, best := summaryCells(summary, nf)
+		b.WriteString("\n\\section*{Comparison}\n\n")
+		writeLaTeXTable(&b, cells, nil, best)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeLaTeXTable writes cells, header row first, as a booktabs tabular
+// with every column but the first right-aligned. A non-nil footer is set
+// off below a rule, and cells flagged in best are set in bold.
+func writeLaTeXTable(b *strings.Builder, cells [][]string, footer []string, best [][]bool) {
+	fmt.Fprintf(b, "\\begin{tabular}{l%s}\n\\toprule\n", strings.Repeat("r", len(cells[0])-1))
+	line := func(row []string, bold []bool) {
+		escaped := make([]string, len(row))
+		for c, cell := range row {
+			escaped[c] = latexEscape(cell)
+			if bold != nil && bold[c] {
+				escaped[c] = "\\textbf{" + escaped[c] + "}"
+			}
+		}
+		b.WriteString(strings.Join(escaped, " & ") + " \\\\\n")
+	}
+	for r, row := range cells {
+		var bold []bool
+		if best != nil {
+			bold = best[r]
+		}
+		line(row, bold)
+		if r == 0 {
+			b.WriteString("\\midrule\n")
+		}
+	}
+	if footer != nil {
+		b.WriteString("\\midrule\n")
+		line(footer, nil)
+	}
+	b.WriteString("\\bottomrule\n\\end{tabular}\n")
+}
+
+// writeTikZGantt draws gantt as a row of boxes in the processes' palette
+// colors, or the idle color for idle slices, labelled like the text chart,
+// scaled to latexGanttWidth, with the time of every slice boundary beneath.
+func writeTikZGantt(b *strings.Builder, gantt []TimeSlice, names map[int64]string, nf numberFormat) {
+	if len(gantt) == 0 {
+		return
+	}
+	start, end := gantt[0].Start, gantt[len(gantt)-1].Stop
+	fmt.Fprintf(b, "\\begin{tikzpicture}[x=%scm, y=0.8cm]\n",
+		strconv.FormatFloat(latexGanttWidth/float64(max(end-start, 1)), 'g', 4, 64))
+	defined := make(map[string]bool)
+	for _, s := range gantt {
+		if name, c := latexFill(s); !defined[name] {
+			defined[name] = true
+			fmt.Fprintf(b, "\\definecolor{%s}{HTML}{%s}\n", name, strings.ToUpper(c.Hex[1:]))
+		}
+	}
+	boundaries := []int64{start}
+	for _, s := range gantt {
+		label := names[s.PID]
+		switch {
+		case s.Idle:
+			label = ganttIdleLabel
+		case label == "":
+			label = strconv.FormatInt(s.PID, 10)
+		}
+		fill, _ := latexFill(s)
+		fmt.Fprintf(b, "\\draw[fill=%s] (%d,0) rectangle (%d,1) node[midway] {\\small %s};\n",
+			fill, s.Start-start, s.Stop-start, latexEscape(label))
+		if s.Start != boundaries[len(boundaries)-1] {
+			boundaries = append(boundaries, s.Start)
+		}
+		boundaries = append(boundaries, s.Stop)
+	}
+	for _, t := range boundaries {
+		fmt.Fprintf(b, "\\node[below] at (%d,0) {\\footnotesize %s};\n", t-start, latexEscape(nf.time(t)))
+	}
+	b.WriteString("\\end{tikzpicture}\n")
+}
+
+// latexFill names the color a slice is filled with and gives its value.
+func latexFill(s TimeSlice) (string, pidColor) {
+	if s.Idle {
+		return "idle", idleColor
+	}
+	return fmt.Sprintf("pid%d", s.PID), colorForPID(s.PID)
+}
+
+// latexEscape escapes the characters LaTeX treats specially in text.
+var latexEscape = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+).Replace
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputLaTeX(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, Name: "cc_1", BurstDuration: 3, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 4},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs"}
+	cfg.Format = "latex"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+	want := "% Requires \\usepackage{booktabs} and \\usepackage{tikz}.\n" +
+		"\n\\section*{First-come, first-serve}\n\n" +
+		"\\begin{tikzpicture}[x=2.8cm, y=0.8cm]\n" +
+		"\\definecolor{pid1}{HTML}{FF7F0E}\n" +
+		"\\definecolor{idle}{HTML}{D62728}\n" +
+		"\\definecolor{pid2}{HTML}{2CA02C}\n" +
+		"\\draw[fill=pid1] (0,0) rectangle (3,1) node[midway] {\\small cc\\_1};\n" +
+		"\\draw[fill=idle] (3,0) rectangle (4,1) node[midway] {\\small IDLE};\n" +
+		"\\draw[fill=pid2] (4,0) rectangle (5,1) node[midway] {\\small 2};\n" +
+		"\\node[below] at (0,0) {\\footnotesize 0};\n" +
+		"\\node[below] at (3,0) {\\footnotesize 3};\n" +
+		"\\node[below] at (4,0) {\\footnotesize 4};\n" +
+		"\\node[below] at (5,0) {\\footnotesize 5};\n" +
+		"\\end{tikzpicture}\n" +
+		"\n\\begin{tabular}{lrrrrrrr}\n\\toprule\n" +
+		"ID & Name & Priority & Burst & Arrival & Wait & Turnaround & Exit \\\\\n\\midrule\n" +
+		"1 & cc\\_1 & 0 & 3 & 0 & 0 & 3 & 3 \\\\\n" +
+		"2 & - & 0 & 1 & 4 & 0 & 1 & 5 \\\\\n\\midrule\n" +
+		"Average &  &  &  &  & 0.00 & 2.00 &  \\\\\n" +
+		"\\bottomrule\n\\end{tabular}\n" +
+		"\nThroughput: 0.40/t\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputLaTeX() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_latexEscape(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in, want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "50% & $5 #1", want: `50\% \& \$5 \#1`},
+		{in: `a_b{c}\~^`, want: `a\_b\{c\}\textbackslash{}\textasciitilde{}\textasciicircum{}`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			if got := latexEscape(tt.in); got != tt.want {
+				t.Errorf("latexEscape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,39 +1,259 @@
 package main
 
 import (
-	"container/heap"
-	"encoding/csv"
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"os"
-	"sort"
-	"strconv"
+	"os/signal"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/olekukonko/tablewriter"
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		if cmd, ok := lookupCommand(os.Args[1]); ok {
+			if err := cmd.Run(os.Stdout, os.Args[2:]...); err != nil {
+				fail(err)
+			}
+			return
+		}
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	cfg, args, err := parseArgs(os.Args...)
 	if err != nil {
-		log.Fatal(err)
+		fail(err)
+	}
+	if cfg.Watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := watch(ctx, os.Stdout, cfg, watchInterval, args...); err != nil {
+			fail(err)
+		}
+		return
 	}
-	defer closeFile()
 
 	// Load and parse processes
-	processes, err := loadProcesses(f)
+	processes, err := loadArgs(cfg, args...)
+	if err != nil {
+		fail(err)
+	}
+
+	stopProfiling, err := startProfiling(cfg)
 	if err != nil {
-		log.Fatal(err)
+		fail(err)
+	}
+	if err := run(os.Stdout, cfg, processes); err != nil {
+		fail(err)
+	}
+	if err := stopProfiling(); err != nil {
+		fail(err)
+	}
+}
+
+// run simulates processes under every configured algorithm and writes
+// the results, or the sweep table or grid CSV when one is configured.
+// Warnings go to stderr, so they never mix with the results.
+func run(w io.Writer, cfg Config, processes []Process) error {
+	warnings := runWarnings(cfg, processes)
+	outputWarnings(os.Stderr, warnings)
+	if len(cfg.Grid) > 0 {
+		return runGrid(w, cfg, processes)
+	}
+	if cfg.Sweep != "" {
+		return runSweep(w, cfg, processes)
+	}
+
+	levels, _ := parseVerbosity(cfg.Verbose)
+	var traceOut io.Writer = os.Stderr
+	if cfg.TraceFile != "" {
+		f, err := os.Create(cfg.TraceFile)
+		if err != nil {
+			return fmt.Errorf("%v: error creating trace file", err)
+		}
+		defer f.Close()
+		traceOut = f
+	}
+
+	var graph *queueGraph
+	if cfg.QueueGraph != "" {
+		graph = &queueGraph{}
+	}
+	var eventOut io.Writer
+	if cfg.EventLog != "" {
+		f, err := os.Create(cfg.EventLog)
+		if err != nil {
+			return fmt.Errorf("%v: error creating event log", err)
+		}
+		defer f.Close()
+		eventOut = f
+	}
+
+	format := cfg.resultFormat()
+	switch {
+	case format.custom():
+	case cfg.Canonical:
+		_, _ = fmt.Fprintln(w, canonicalVersion)
+	case !cfg.Quiet:
+		outputRunHeader(w, cfg, processes)
+	}
+	var (
+		summary = make([]summaryRow, 0, len(cfg.Algorithms))
+		usage   []resourceUsage
+		stepIn  = bufio.NewScanner(os.Stdin)
+	)
+	for _, name := range cfg.Algorithms {
+		s, _ := lookupScheduler(name)
+		var stepObserver, traceObserver, graphObserver, eventObserver, explainObserver func(d decision)
+		if cfg.Step {
+			stepObserver = newStepper(stepIn, w, s.Title, cfg).observe
+		}
+		if cfg.Explain {
+			explainObserver = newExplainer(w, s.Title).observe
+		}
+		if level := verbosityFor(levels, name); level > traceOff {
+			traceObserver = tracer{w: traceOut, name: name, level: level}.observe
+		}
+		if graph != nil {
+			graphObserver = graph.algorithm(s.Title)
+		}
+		opts := cfg.simOptions()
+		if format.hooks != nil {
+			opts.hooks = format.hooks(w, s.Name)
+		}
+		if eventOut != nil {
+			log := newEventLog(eventOut, s.Title, processes, cfg.numberFormat())
+			eventObserver = log.observe
+			opts.hooks = chainHooks(opts.hooks, log.hooks())
+		}
+		opts.observer = chainObservers(traceObserver, explainObserver, stepObserver, graphObserver, eventObserver)
+		var meter resourceMeter
+		if cfg.ResourceUsage {
+			meter = startResourceMeter()
+		}
+		r := simulate(processes, s.NewPolicy(cfg), opts)
+		r.Gantt = downsampleGantt(r.Gantt, cfg.GanttRes)
+		if cfg.ResourceUsage {
+			usage = append(usage, meter.stop(s.Name))
+		}
+		switch {
+		case format.custom():
+		case cfg.Canonical:
+			outputCanonical(w, s.Name, r)
+		default:
+			outputResult(w, s.Title, r, cfg.outputOptions())
+		}
+		summary = append(summary, summaryRow{Scheduler: s, Result: r})
+	}
+
+	if format.write != nil {
+		if err := format.write(w, cfg, warnings, summary); err != nil {
+			return err
+		}
+	} else if cfg.Compare && !cfg.Canonical && !format.custom() {
+		outputComparison(w, summary, cfg.outputOptions())
+	}
+	if cfg.ResourceUsage {
+		outputResourceUsage(os.Stderr, usage)
+	}
+	if cfg.SummaryImage != "" {
+		if err := writeSummaryImage(cfg.SummaryImage, summary, cfg.numberFormat()); err != nil {
+			return err
+		}
+	}
+	if cfg.GanttImage != "" {
+		if err := writeGanttImage(cfg.GanttImage, summary, cfg.outputOptions()); err != nil {
+			return err
+		}
 	}
+	if graph != nil {
+		return graph.writeFile(cfg.QueueGraph)
+	}
+	return nil
+}
 
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	RRSchedule(os.Stdout, "Round-robin", processes)
+// Scheduler is a registered scheduling algorithm selectable by name.
+// Params lists the Config knobs the algorithm is sensitive to.
+type Scheduler struct {
+	Name        string
+	Title       string
+	Description string
+	Params      []string
+	NewPolicy   func(cfg Config) policy
+}
+
+var schedulers = []Scheduler{
+	{
+		Name:        "fcfs",
+		Title:       "First-come, first-serve",
+		Description: "Runs processes to completion in arrival order.",
+		Params:      []string{"sched_overhead"},
+		NewPolicy: func(Config) policy {
+			return &fcfsPolicy{}
+		},
+	},
+	{
+		Name:        "sjf",
+		Title:       "Shortest-job-first",
+		Description: "Runs the shortest remaining burst first, preempting for shorter arrivals.",
+		Params:      []string{"sched_overhead", "run_queue"},
+		NewPolicy: func(cfg Config) policy {
+			return newSJFPolicy(cfg.RunQueue)
+		},
+	},
+	{
+		Name:        "priority",
+		Title:       "Priority",
+		Description: "Runs the lowest priority value first, preempting for more urgent arrivals.",
+		Params:      []string{"sched_overhead", "run_queue"},
+		NewPolicy: func(cfg Config) policy {
+			return newPriorityPolicy(cfg.RunQueue)
+		},
+	},
+	{
+		Name:        "rr",
+		Title:       "Round-robin",
+		Description: "Rotates through ready processes, preempting each after one quantum.",
+		Params:      []string{"quantum", "sched_overhead"},
+		NewPolicy: func(cfg Config) policy {
+			return &rrPolicy{q: cfg.Quantum}
+		},
+	},
+}
+
+// Schedule simulates processes under the algorithm configured by cfg.
+func (s Scheduler) Schedule(processes []Process, cfg Config) Result {
+	return simulate(processes, s.NewPolicy(cfg), cfg.simOptions())
+}
+
+func lookupScheduler(name string) (Scheduler, bool) {
+	for _, s := range schedulers {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Scheduler{}, false
+}
+
+// loadArgs loads the workload named on the command line, or with --merge
+// every workload named there as one.
+func loadArgs(cfg Config, args ...string) ([]Process, error) {
+	if cfg.Merge {
+		return mergeWorkloads(args[1:], cfg.loadOptions())
+	}
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+	return loadWorkload(f.Name(), f, cfg.loadOptions())
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
@@ -43,11 +263,11 @@ func openProcessingFile(args ...string) (*os.File, func(), error) {
 	// Read in CSV process CSV file
 	f, err := os.Open(args[1])
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+		return nil, nil, fmt.Errorf("%w: %v: error opening scheduling file", ErrInvalidArgs, err)
 	}
 	closeFn := func() {
 		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
+			fail(fmt.Errorf("%v: error closing scheduling file", err))
 		}
 	}
 
@@ -56,370 +276,509 @@ func openProcessingFile(args ...string) (*os.File, func(), error) {
 
 type (
 	Process struct {
-		ProcessID     int64
+		ProcessID int64
+		// Name is an optional label, such as "editor", shown in place of
+		// or beside the PID.
+		Name string
+		// Owner is the optional user the process runs as, by which
+		// reports break its metrics down.
+		Owner         string
 		ArrivalTime   int64
 		BurstDuration int64
 		Priority      int64
+		// Deadline is the time by which the process should have
+		// completed, or zero for none.
+		Deadline int64
+		// Bursts, when given, are the CPU and I/O bursts the process
+		// alternates between, and BurstDuration their total CPU time.
+		Bursts []Burst
+		// Affinity is a bitmask of the CPUs the process may run on, bit n
+		// for CPU n, or zero for any.
+		Affinity uint64
+		// DependsOn lists the PIDs of processes that must complete
+		// before this one becomes ready.
+		DependsOn []int64
+		// Nice is the process's Unix nice value, when one was given.
+		Nice int64
+		// Weight is its share of the CPU for weighted schedulers, such as
+		// lottery tickets or a CFS load weight, given directly or by its
+		// nice value; it is zero when neither was given.
+		Weight int64
+		// Period, when set, makes the process a periodic task, which the
+		// workload loader expands into a job every Period ticks, each
+		// released up to Jitter ticks late.
+		Period int64
+		Jitter int64
 	}
+	// TimeSlice is a span of the Gantt chart: a process running, or with
+	// Idle set, the CPU idle with no process ready, its PID then unused.
 	TimeSlice struct {
 		PID   int64
 		Start int64
 		Stop  int64
+		Idle  bool
+	}
+	// ScheduleRow is one process's line in the schedule table. Response is
+	// the time from arrival until the process first gets the CPU; IO is the
+	// time it spent blocked on I/O, which does not count as waiting;
+	// MissedBy is how long after its deadline, if it has one, it exited.
+	ScheduleRow struct {
+		ProcessID  int64
+		Name       string
+		Owner      string
+		Priority   int64
+		Burst      int64
+		Arrival    int64
+		Wait       int64
+		Turnaround int64
+		Response   int64
+		Exit       int64
+		IO         int64
+		Deadline   int64
+		MissedBy   int64
+	}
+	// Result is the outcome of running one scheduling algorithm. Gantt
+	// runs from time 0, with idle slices where no process was ready; gaps
+	// between slices are scheduler overhead. Busy, Overhead and Idle split
+	// the CPU time up to the last completion; Makespan runs from the first
+	// arrival to the last completion.
+	Result struct {
+		Gantt           []TimeSlice
+		Schedule        []ScheduleRow
+		AveWait         float64
+		AveTurnaround   float64
+		AveResponse     float64
+		AveThroughput   float64
+		Decisions       int
+		ContextSwitches int
+		Busy            int64
+		Overhead        int64
+		Idle            int64
+		Makespan        int64
+		// Metrics holds the values of registered custom metrics by name.
+		Metrics map[string]float64
+		// Blocked holds the spans processes spent blocked on I/O, in the
+		// order they began.
+		Blocked []TimeSlice
 	}
 )
 
 func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime        int64
-		totalWait          float64
-		totalTurnaround    float64
-		lastCompletionTime float64
-		waitingTime        int64
-		schedule           = make([][]string, len(processes))
-		gantt              = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+	outputResult(w, title, simulate(processes, &fcfsPolicy{}, simOptions{}), outputOptions{})
+}
 
-		start := waitingTime + processes[i].ArrivalTime
+func SJFSchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{}), outputOptions{})
+}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+	outputResult(w, title, simulate(processes, newPriorityPolicy(defaultRunQueue), simOptions{}), outputOptions{})
+}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletionTime = float64(completion)
+func RRSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	outputResult(w, title, simulate(processes, &rrPolicy{q: quantum}, simOptions{}), outputOptions{})
+}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
+// outputOptions control how results are rendered, independently of how
+// they were computed.
+type outputOptions struct {
+	color bool
+	// quiet prints only the averages and throughput of each algorithm.
+	quiet bool
+	// matrix adds the timeline matrix after the Gantt chart.
+	matrix bool
+	// from and to restrict the rendered timeline to [from, to), in ticks;
+	// a zero to leaves it open-ended. Metrics always cover the whole
+	// simulation.
+	from, to int64
+	// unit labels and scales rendered times. The zero value means ticks
+	// shown as "t".
+	numbers numberFormat
+	// maxRows and maxSlices cap how much of the schedule table and Gantt
+	// chart is printed; zero prints everything. Metrics are unaffected.
+	maxRows, maxSlices int
+	// sortBy orders the schedule table; the zero value keeps arrival order.
+	sortBy sortKey
+	// columns selects the schedule table columns; nil means the defaults.
+	columns []scheduleColumn
+	// stream writes the schedule table row by row instead of buffering it.
+	stream bool
+	// tableRows, when set, are the rows the schedule table lists, as they
+	// are, for a table split across files; the Gantt chart and breakdowns
+	// still cover every process.
+	tableRows []ScheduleRow
+}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+func (c Config) outputOptions() outputOptions {
+	sortBy, _ := parseSortKey(c.SortBy)
+	columns, _ := parseColumns(c.Columns)
+	return outputOptions{
+		color:     c.Color,
+		quiet:     c.Quiet,
+		matrix:    c.Matrix,
+		from:      c.From,
+		to:        c.To,
+		numbers:   c.numberFormat(),
+		maxRows:   c.MaxRows,
+		maxSlices: c.MaxSlices,
+		sortBy:    sortBy,
+		columns:   columns,
+		stream:    c.Stream,
 	}
+}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletionTime
-
+func outputResult(w io.Writer, title string, r Result, opts outputOptions) {
+	if opts.quiet {
+		_, _ = fmt.Fprintf(w, "%s: average wait %s, average turnaround %s, throughput %s\n",
+			title, opts.numbers.duration(r.AveWait), opts.numbers.duration(r.AveTurnaround), opts.numbers.rate(r.AveThroughput))
+		return
+	}
 	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	outputGantt(w, clipGantt(r.Gantt, opts.from, opts.to), processNames(r.Schedule), opts)
+	if r.Idle > 0 && r.Overhead == 0 {
+		outputIdle(w, r, opts.numbers)
+	}
+	if opts.matrix {
+		outputMatrix(w, r, opts)
+	}
+	outputSchedule(w, r, opts)
+	for _, line := range customMetricLines(r, metricPlugins, opts.numbers) {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if owners := ownerBreakdown(r.Schedule); owners != nil {
+		outputOwners(w, owners, r.Busy, opts.numbers)
+	}
+	if r.Overhead > 0 {
+		outputUtilization(w, r, opts.numbers)
+	}
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	// Sort processes by arrival time
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].ArrivalTime < processes[j].ArrivalTime
-	})
+// outputRunHeader records the settings that shape every schedule of the
+// run, so saved output can be told apart and reproduced.
+func outputRunHeader(w io.Writer, cfg Config, processes []Process) {
+	_, _ = fmt.Fprintf(w, "Run: %d processes, quantum %s, arrival ties: %s\n\n",
+		len(processes), cfg.numberFormat().time(cfg.Quantum), arrivalTies[cfg.ArrivalTies])
+}
 
-	var (
-		currentIndex       int
-		currentTime        int64
-		lastCompletionTime float64
-		schedule           = make([][]string, len(processes))
-		gantt              = make([]TimeSlice, 0)
-		remainingTime      = make([]int64, len(processes))
-		waitingTimes       = make([]int64, len(processes))
-	)
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
 
-	for i, p := range processes {
-		remainingTime[i] = p.BurstDuration
+// clipGantt returns the parts of the slices that fall inside [from, to),
+// trimming slices that straddle either edge. A zero to means no upper bound.
+func clipGantt(gantt []TimeSlice, from, to int64) []TimeSlice {
+	if from == 0 && to == 0 {
+		return gantt
 	}
-
-	var (
-		numOfCompletedProcesses int
-		totalProcessCount       = len(processes)
-	)
-
-	for numOfCompletedProcesses < totalProcessCount {
-
-		minRemainTime := int64(math.MaxInt64)
-		for i, p := range processes {
-			if p.ArrivalTime <= currentTime && remainingTime[i] < minRemainTime && remainingTime[i] > 0 {
-				minRemainTime = remainingTime[i]
-				currentIndex = i
-			}
-		}
-
-		if minRemainTime == int64(math.MaxInt64) {
-			currentTime = processes[numOfCompletedProcesses].ArrivalTime
+	clipped := make([]TimeSlice, 0, len(gantt))
+	for _, ts := range gantt {
+		if ts.Stop <= from || (to != 0 && ts.Start >= to) {
 			continue
 		}
-
-		if len(gantt) == 0 || gantt[len(gantt)-1].PID != processes[currentIndex].ProcessID {
-			timeSlice := TimeSlice{
-				PID:   processes[currentIndex].ProcessID,
-				Start: currentTime,
-			}
-			gantt = append(gantt, timeSlice)
+		if ts.Start < from {
+			ts.Start = from
 		}
-
-		remainingTime[currentIndex]--
-		currentTime++
-
-		if remainingTime[currentIndex] == 0 {
-			numOfCompletedProcesses++
-			stop := currentTime
-			gantt[len(gantt)-1].Stop = stop
-
-			waitingTimes[currentIndex] = stop - processes[currentIndex].BurstDuration - processes[currentIndex].ArrivalTime
-			if waitingTimes[currentIndex] < 0 {
-				waitingTimes[currentIndex] = 0
-			}
-
-			completion := float64(processes[currentIndex].BurstDuration + processes[currentIndex].ArrivalTime + waitingTimes[currentIndex])
-			if lastCompletionTime < completion {
-				lastCompletionTime = completion
-			}
-
-			schedule[currentIndex] = []string{
-				fmt.Sprint(processes[currentIndex].ProcessID),
-				fmt.Sprint(processes[currentIndex].Priority),
-				fmt.Sprint(processes[currentIndex].BurstDuration),
-				fmt.Sprint(processes[currentIndex].ArrivalTime),
-				fmt.Sprint(waitingTimes[currentIndex]),
-				fmt.Sprint(waitingTimes[currentIndex] + processes[currentIndex].BurstDuration),
-				fmt.Sprint(completion),
-			}
+		if to != 0 && ts.Stop > to {
+			ts.Stop = to
 		}
+		clipped = append(clipped, ts)
 	}
-
-	aveWait := float64(totalWaitingTime(waitingTimes)) / float64(totalProcessCount)
-	aveTurnaround := float64(totalTurnAroundTime(processes, waitingTimes)) / float64(totalProcessCount)
-	aveThroughput := float64(totalProcessCount) / float64(lastCompletionTime)
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return clipped
 }
 
-func totalTurnAroundTime(processes []Process, waitingTimes []int64) int64 {
-	turnAroundTimes := make([]int64, len(processes))
-	result := int64(0)
-
-	for i := range processes {
-		turnAroundTimes[i] = processes[i].BurstDuration + waitingTimes[i]
-		result += turnAroundTimes[i]
+// fillIdle returns gantt with the gaps before and between its slices,
+// from time 0, filled with idle slices, for schedules not simulated here,
+// where any time the CPU did not run a process was idle.
+func fillIdle(gantt []TimeSlice) []TimeSlice {
+	filled := make([]TimeSlice, 0, len(gantt))
+	var prev int64
+	for _, s := range gantt {
+		if s.Start > prev {
+			filled = append(filled, TimeSlice{Start: prev, Stop: s.Start, Idle: true})
+		}
+		filled = append(filled, s)
+		prev = max(prev, s.Stop)
 	}
-	return result
+	return filled
 }
 
-func totalWaitingTime(waitingTimes []int64) int64 {
-	result := int64(0)
-
-	for i := range waitingTimes {
-		result += waitingTimes[i]
+// downsampleGantt coarsens gantt to buckets of the given number of ticks,
+// for runs too long to chart slice by slice: each bucket goes to the
+// process, or idle time, that took longest in it, the earliest on a tie,
+// or is left a gap if no slice covered that much, and neighbouring
+// buckets that went the same way merge into one slice.
+func downsampleGantt(gantt []TimeSlice, bucket int64) []TimeSlice {
+	if bucket <= 1 || len(gantt) == 0 {
+		return gantt
 	}
-	return result
-}
-
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].ArrivalTime < processes[j].ArrivalTime
-	})
-
 	var (
-		totalProcessCount  = len(processes)
-		turnAroundTimes    = make([]int64, totalProcessCount)
-		waitingTimes       = make([]int64, totalProcessCount)
-		gantt              = make([]TimeSlice, 0)
-		lastCompletionTime = int64(0)
-		schedule           = make([][]string, totalProcessCount)
-		insertedProcessIdx = 0
-		totalBurstDuration = int64(0)
-		minHeap            = &IntHeap{}
+		first, last = gantt[0].Start, gantt[len(gantt)-1].Stop
+		downsampled []TimeSlice
+		current     = first / bucket
+		ran         = make(map[TimeSlice]int64) // by PID and Idle alone
+		order       []TimeSlice
 	)
-
-	heap.Init(minHeap)
-
-	currentTime := processes[0].ArrivalTime
-	processesMapIndex := make(map[int64]int)
-	for i, p := range processes {
-		processesMapIndex[p.ProcessID] = i
-		totalBurstDuration += p.BurstDuration
-	}
-
-	for {
-		for insertedProcessIdx < totalProcessCount && processes[insertedProcessIdx].ArrivalTime == currentTime {
-			heap.Push(minHeap, processes[insertedProcessIdx])
-			insertedProcessIdx++
-		}
-
-		if minHeap.Len() == 0 {
-			if insertedProcessIdx < totalProcessCount {
-				currentTime = processes[insertedProcessIdx].ArrivalTime
-			} else {
-				break
+	flush := func() {
+		start, stop := max(current*bucket, first), min((current+1)*bucket, last)
+		var (
+			winner        TimeSlice
+			longest, busy int64
+		)
+		for _, lane := range order {
+			busy += ran[lane]
+			if ran[lane] > longest {
+				winner, longest = lane, ran[lane]
 			}
 		}
-
-		minPriorityProcess := heap.Pop(minHeap).(Process)
-		minPriorityProcess.BurstDuration--
-
-		if len(gantt) == 0 || gantt[len(gantt)-1].PID != minPriorityProcess.ProcessID {
-			timeSlice := TimeSlice{
-				PID:   minPriorityProcess.ProcessID,
-				Start: currentTime,
-				Stop:  currentTime + minPriorityProcess.BurstDuration + 1,
+		if longest >= stop-start-busy {
+			if n := len(downsampled); n > 0 && downsampled[n-1].PID == winner.PID && downsampled[n-1].Idle == winner.Idle && downsampled[n-1].Stop == start {
+				downsampled[n-1].Stop = stop
+			} else {
+				winner.Start, winner.Stop = start, stop
+				downsampled = append(downsampled, winner)
 			}
-			gantt = append(gantt, timeSlice)
 		}
-
-		currentTime++
-
-		if minPriorityProcess.BurstDuration == 0 {
-			idx := processesMapIndex[minPriorityProcess.ProcessID]
-			completionTime := currentTime
-			turnAroundTimes[idx] = completionTime - minPriorityProcess.ArrivalTime
-			waitingTimes[idx] = turnAroundTimes[idx] - minPriorityProcess.BurstDuration - minPriorityProcess.ArrivalTime
-			if lastCompletionTime < completionTime {
-				lastCompletionTime = completionTime
+		clear(ran)
+		order = order[:0]
+	}
+	for _, s := range gantt {
+		for t := s.Start; t < s.Stop; {
+			if k := t / bucket; k != current {
+				flush()
+				current = k
 			}
-		} else {
-			heap.Push(minHeap, minPriorityProcess)
-			if lastCompletionTime < currentTime {
-				lastCompletionTime = currentTime
+			end := min(s.Stop, (current+1)*bucket)
+			lane := TimeSlice{PID: s.PID, Idle: s.Idle}
+			if _, ok := ran[lane]; !ok {
+				order = append(order, lane)
 			}
+			ran[lane] += end - t
+			t = end
 		}
-
 	}
+	flush()
+	return downsampled
+}
 
-	for i, p := range processes {
-		completion := p.ArrivalTime + p.BurstDuration + waitingTimes[i]
-		if lastCompletionTime < completion {
-			lastCompletionTime = completion
-		}
-		schedule[i] = []string{
-			fmt.Sprint(p.ProcessID),
-			fmt.Sprint(p.Priority),
-			fmt.Sprint(p.BurstDuration),
-			fmt.Sprint(p.ArrivalTime),
-			fmt.Sprint(waitingTimes[i]),
-			fmt.Sprint(turnAroundTimes[i]),
-			fmt.Sprint(completion),
+// processNames maps the PID of every named process onto its name.
+func processNames(rows []ScheduleRow) map[int64]string {
+	names := make(map[int64]string)
+	for _, row := range rows {
+		if row.Name != "" {
+			names[row.ProcessID] = row.Name
 		}
 	}
+	return names
+}
 
-	aveWait := float64(total(waitingTimes)) / float64(totalProcessCount)
-	aveTurnaround := float64(total(turnAroundTimes)) / float64(totalProcessCount)
-	aveThroughput := float64(totalProcessCount) / float64(lastCompletionTime)
+// The Gantt chart gives each time unit ganttUnitWidth columns, scaled
+// down for long runs so the chart fits in about ganttMaxWidth.
+const (
+	ganttUnitWidth = 2
+	ganttMaxWidth  = 80
+)
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
-}
+// ganttIdleLabel marks the time the CPU spends idle in the Gantt chart.
+const ganttIdleLabel = "IDLE"
 
-func total(arr []int64) int64 {
-	result := int64(0)
-	for _, value := range arr {
-		result += value
+// ganttLabel is the label of a process's Gantt cells: its name, shortened
+// to fit width columns, or else its PID.
+func ganttLabel(pid int64, names map[int64]string, width int) string {
+	label, ok := names[pid]
+	if !ok {
+		label = fmt.Sprint(pid)
 	}
-	return result
+	return cutLabel(label, width)
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
-	outputTitle(w, title)
+// cutLabel shortens label to fit width columns, ending it with "…" when
+// it had to be cut.
+func cutLabel(label string, width int) string {
+	if runes := []rune(label); len(runes) > width {
+		return string(runes[:max(width-1, 0)]) + "…"
+	}
+	return label
 }
 
-type IntHeap []Process
-
-func (h IntHeap) Len() int { return len(h) }
-
-func (h IntHeap) Less(i, j int) bool {
-	return h[i].Priority < h[j].Priority
+// ganttCell is one cell of the Gantt chart: a slice, or a gap between two
+// where the CPU ran the scheduler itself.
+type ganttCell struct {
+	TimeSlice
+	gap   bool
+	width int
 }
 
-func (h IntHeap) Swap(i, j int) {
-	h[i], h[j] = h[j], h[i]
+// ganttCells lays gantt out as cells as wide as their share of the time,
+// each at least wide enough for the time printed under its left edge, so
+// that the times never run into each other.
+func ganttCells(gantt []TimeSlice, nf numberFormat) []ganttCell {
+	cells := make([]ganttCell, 0, len(gantt))
+	for i, s := range gantt {
+		if i > 0 && gantt[i-1].Stop < s.Start {
+			cells = append(cells, ganttCell{TimeSlice: TimeSlice{Start: gantt[i-1].Stop, Stop: s.Start}, gap: true})
+		}
+		cells = append(cells, ganttCell{TimeSlice: s})
+	}
+	if len(cells) == 0 {
+		return cells
+	}
+	start, span := cells[0].Start, cells[len(cells)-1].Stop-cells[0].Start
+	scale := float64(ganttUnitWidth) / float64(max(nf.Resolution, 1))
+	if float64(span)*scale > ganttMaxWidth {
+		scale = ganttMaxWidth / float64(span)
+	}
+	column := func(t int64) int { return int(math.Round(float64(t-start) * scale)) }
+	for i := range cells {
+		cells[i].width = max(column(cells[i].Stop)-column(cells[i].Start), utf8.RuneCountInString(nf.at(cells[i].Start)), 1)
+	}
+	return cells
 }
 
-func (h *IntHeap) Push(x any) {
-	*h = append(*h, x.(Process))
-}
+func outputGantt(w io.Writer, gantt []TimeSlice, names map[int64]string, opts outputOptions) {
+	var more int
+	if opts.maxSlices > 0 && len(gantt) > opts.maxSlices {
+		more = len(gantt) - opts.maxSlices
+		gantt = gantt[:opts.maxSlices]
+	}
+	cells := ganttCells(gantt, opts.numbers)
+	var bars, times strings.Builder
+	bars.WriteString("|")
+	for _, c := range cells {
+		var label string
+		switch {
+		case c.Idle:
+			label = cutLabel(ganttIdleLabel, c.width)
+		case !c.gap:
+			label = ganttLabel(c.PID, names, c.width)
+		}
+		left := (c.width - utf8.RuneCountInString(label)) / 2
+		cell := strings.Repeat(" ", left) + label + strings.Repeat(" ", c.width-left-utf8.RuneCountInString(label))
+		switch {
+		case !opts.color || c.gap:
+		case c.Idle:
+			cell = ansiBackground(idleColor, cell)
+		default:
+			cell = ansiBackground(colorForPID(c.PID), cell)
+		}
+		bars.WriteString(cell + "|")
 
-func (h *IntHeap) Pop() any {
-	old := *h
-	n := len(old)
-	x := old[n-1]
-	*h = old[0 : n-1]
-	return x
+		at := opts.numbers.at(c.Start)
+		times.WriteString(at + strings.Repeat(" ", c.width+1-utf8.RuneCountInString(at)))
+	}
+	if len(cells) > 0 {
+		times.WriteString(opts.numbers.at(cells[len(cells)-1].Stop))
+	}
+	if more > 0 {
+		bars.WriteString(fmt.Sprintf(" … %d more", more))
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	_, _ = fmt.Fprintln(w, bars.String())
+	_, _ = fmt.Fprintln(w, times.String())
+	if opts.color {
+		outputLegend(w, gantt)
+	}
+	_, _ = fmt.Fprintln(w)
 }
 
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-}
+// outputSchedule prints the per-process table with the selected columns.
+// Columns that have one get their average in the footer, and throughput
+// goes in the rightmost footer cell left free.
+func outputSchedule(w io.Writer, r Result, opts outputOptions) {
+	columns := opts.columns
+	if columns == nil {
+		columns = defaultColumnsFor(r.Schedule)
+	}
+	rows := opts.sortBy.sort(r.Schedule)
+	var more int
+	if opts.tableRows != nil {
+		rows = opts.tableRows
+	} else if opts.maxRows > 0 && len(rows) > opts.maxRows {
+		more = len(rows) - opts.maxRows
+		rows = rows[:opts.maxRows]
+	}
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+	// The labels are uppercased here rather than by tablewriter, which
+	// would uppercase the footer's values too, time unit and all.
+	header := make([]string, len(columns))
+	footer := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(opts.numbers.header(c.Header))
+		if c.Average != nil {
+			footer[i] = strings.ToUpper(opts.numbers.header("Average")) + "\n" + opts.numbers.duration(c.Average(r))
+		}
 	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+	for i := len(footer) - 1; i >= 0; i-- {
+		if footer[i] == "" {
+			footer[i] = strings.ToUpper(opts.numbers.header("Throughput")) + "\n" + opts.numbers.rate(r.AveThroughput)
+			break
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
 	_, _ = fmt.Fprintln(w, "Schedule table")
+	if opts.stream {
+		streamSchedule(w, rows, columns, header, footer, opts.numbers)
+	} else {
+		table := tablewriter.NewWriter(w)
+		table.SetAutoFormatHeaders(false)
+		table.SetHeader(header)
+		// Localized numbers are not recognized as numbers by tablewriter.
+		table.SetAlignment(tablewriter.ALIGN_RIGHT)
+		for _, row := range rows {
+			cells := make([]string, len(columns))
+			for i, c := range columns {
+				cells[i] = c.format(row, opts.numbers)
+			}
+			table.Append(cells)
+		}
+		table.SetFooter(footer)
+		table.Render()
+	}
+	if more > 0 {
+		_, _ = fmt.Fprintf(w, "… %d more\n", more)
+	}
+}
+
+// outputUtilization breaks the CPU time down by consumer, giving the
+// scheduler's own decision overhead a row of its own.
+func outputUtilization(w io.Writer, r Result, nf numberFormat) {
+	elapsed := r.Busy + r.Overhead + r.Idle
+	share := func(t int64) string {
+		return nf.float(100*float64(t)/float64(elapsed)) + "%"
+	}
+	_, _ = fmt.Fprintln(w, "Utilization")
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
+	table.SetHeader([]string{nf.header("Consumer"), nf.header("Time"), nf.header("Share")})
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+	table.AppendBulk([][]string{
+		{nf.header("processes"), nf.time(r.Busy), share(r.Busy)},
+		{nf.header("scheduler overhead"), nf.time(r.Overhead), share(r.Overhead)},
+		{nf.header("idle"), nf.time(r.Idle), share(r.Idle)},
+	})
+	table.SetFooter([]string{nf.header("Decisions"), fmt.Sprint(r.Decisions), ""})
 	table.Render()
 }
 
+// outputIdle prints how long the CPU sat idle with no process ready, from
+// time 0 to the last completion as the Gantt chart's IDLE slices show,
+// which the utilization table shows instead when there is overhead.
+func outputIdle(w io.Writer, r Result, nf numberFormat) {
+	_, _ = fmt.Fprintf(w, "CPU idle: %s of %s (%s%%)\n\n",
+		nf.time(r.Idle), nf.time(r.Busy+r.Idle), nf.float(100*float64(r.Idle)/float64(r.Busy+r.Idle)))
+}
+
 var ErrInvalidArgs = errors.New("invalid args")
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
+// loadProcesses reads a workload CSV for simulation. A leading header row
+// such as "pid,burst,arrival,priority" maps columns by name, in any order;
+// without one the columns are positional. Times given as durations are
+// converted with ticks of length tick. Every bad cell is reported, with
+// its line and column, before the workload is rejected.
+func loadProcesses(r io.Reader, opts loadOptions) ([]Process, error) {
+	processes, problems := parseWorkload(r, opts)
+	if problems = wholeWorkloadProblems(processes, problems); len(problems) > 0 {
+		return nil, workloadError("", problems)
 	}
-
 	return processes, nil
 }
-
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
-	}
-	return i
-}
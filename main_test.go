@@ -64,6 +64,60 @@ func TestFCFSSchedule(t *testing.T) {
 	}
 }
 
+func TestRRSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		title     string
+		quantum   int64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   3,
+						BurstDuration: 9,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   6,
+						BurstDuration: 6,
+						Priority:      3,
+					},
+				},
+				title:   "Round-robin",
+				quantum: 2,
+			},
+			wantOut: loadFixture(t, "rr_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			RRSchedule(&w, tt.args.title, tt.args.processes, tt.args.quantum)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("RRSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
 func Test_loadProcesses(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -74,6 +128,7 @@ func Test_loadProcesses(t *testing.T) {
 		args    args
 		want    []Process
 		wantErr error
+		wantMsg string
 	}{
 		{
 			name: "bad CSV",
@@ -110,18 +165,57 @@ func Test_loadProcesses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "header row in any order",
+			args: args{
+				r: strings.NewReader("Arrival,pid,burst\n0,1,5\n3,2,9\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "deadline column",
+			args: args{
+				r: strings.NewReader("1,5,0,2,8\n2,9,3,1,0\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2, Deadline: 8},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "unknown header column",
+			args: args{
+				r: strings.NewReader("pid,burst,arrival,colour\n1,5,0,9\n"),
+			},
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name: "every bad cell is reported",
+			args: args{
+				r: strings.NewReader("1,5,0,2\n2,abc,3\n3,6,x,1\n"),
+			},
+			wantErr: ErrInvalidWorkload,
+			wantMsg: `line 2, column 2: burst duration must be an integer or a duration such as 150ms, got "abc"; ` +
+				`line 3, column 3: arrival time must be an integer or a duration such as 150ms, got "x"`,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := loadProcesses(tt.args.r)
+			got, err := loadProcesses(tt.args.r, loadOptions{})
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("loadProcesses() = %v, want %v", got, tt.want)
 			}
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("error = %v, want %v", err, tt.wantErr)
 			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantMsg)
+			}
 		})
 	}
 }
@@ -205,3 +299,324 @@ func Test_openProcessingFile1(t *testing.T) {
 		})
 	}
 }
+
+func Test_clipGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 5, Stop: 14},
+		{PID: 3, Start: 14, Stop: 20},
+	}
+	type args struct {
+		from, to int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want []TimeSlice
+	}{
+		{
+			name: "no window",
+			want: gantt,
+		},
+		{
+			name: "trims straddling slices",
+			args: args{from: 3, to: 16},
+			want: []TimeSlice{
+				{PID: 1, Start: 3, Stop: 5},
+				{PID: 2, Start: 5, Stop: 14},
+				{PID: 3, Start: 14, Stop: 16},
+			},
+		},
+		{
+			name: "open-ended",
+			args: args{from: 14},
+			want: []TimeSlice{
+				{PID: 3, Start: 14, Stop: 20},
+			},
+		},
+		{
+			name: "outside the run",
+			args: args{from: 30, to: 40},
+			want: []TimeSlice{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := clipGantt(gantt, tt.args.from, tt.args.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clipGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_downsampleGantt(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3},
+		{PID: 2, Start: 3, Stop: 4},
+		{PID: 1, Start: 4, Stop: 6},
+		{PID: 3, Start: 10, Stop: 12},
+	}
+	tests := []struct {
+		name   string
+		bucket int64
+		want   []TimeSlice
+	}{
+		{
+			name:   "unset",
+			bucket: 0,
+			want:   gantt,
+		},
+		{
+			name:   "one tick",
+			bucket: 1,
+			want:   gantt,
+		},
+		{
+			name:   "merges the longest runner's buckets",
+			bucket: 3,
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6},
+				{PID: 3, Start: 9, Stop: 12},
+			},
+		},
+		{
+			name:   "mostly idle bucket stays idle",
+			bucket: 5,
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 3, Start: 10, Stop: 12},
+			},
+		},
+		{
+			name:   "tie with idle goes to the process",
+			bucket: 4,
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 8},
+				{PID: 3, Start: 8, Stop: 12},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := downsampleGantt(gantt, tt.bucket); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("downsampleGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputResult(t *testing.T) {
+	t.Parallel()
+	r := Result{
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}},
+		Schedule: []ScheduleRow{
+			{ProcessID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 0, Turnaround: 5, Exit: 5},
+			{ProcessID: 2, Priority: 1, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Response: 4, Exit: 8},
+		},
+		AveWait:       2,
+		AveTurnaround: 6,
+		AveResponse:   2,
+		AveThroughput: 0.25,
+	}
+	idWaitResponse, err := parseColumns([]string{"id", "wait", "response"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		opts    outputOptions
+		wantOut string
+	}{
+		{
+			name:    "quiet",
+			opts:    outputOptions{quiet: true},
+			wantOut: "FCFS: average wait 2.00, average turnaround 6.00, throughput 0.25/t\n",
+		},
+		{
+			name: "truncated",
+			opts: outputOptions{maxRows: 1, maxSlices: 1},
+			wantOut: "--------\n   FCFS\n--------\n" +
+				"Gantt schedule\n|    1     | … 1 more\n0          5\n\n" +
+				"Schedule table\n" +
+				"+----+----------+-------+---------+---------+------------+------------+\n" +
+				"| ID | PRIORITY | BURST | ARRIVAL |  WAIT   | TURNAROUND |    EXIT    |\n" +
+				"+----+----------+-------+---------+---------+------------+------------+\n" +
+				"|  1 |        2 |     5 |       0 |       0 |          5 |          5 |\n" +
+				"+----+----------+-------+---------+---------+------------+------------+\n" +
+				"|                                   AVERAGE |  AVERAGE   | THROUGHPUT |\n" +
+				"|                                    2.00   |    6.00    |   0.25/t   |\n" +
+				"+----+----------+-------+---------+---------+------------+------------+\n" +
+				"… 1 more\n",
+		},
+		{
+			name: "selected columns",
+			opts: outputOptions{columns: idWaitResponse},
+			wantOut: "--------\n   FCFS\n--------\n" +
+				"Gantt schedule\n|    1     |  2   |\n0          5      8\n\n" +
+				"Schedule table\n" +
+				"+------------+---------+----------+\n" +
+				"|     ID     |  WAIT   | RESPONSE |\n" +
+				"+------------+---------+----------+\n" +
+				"|          1 |       0 |        0 |\n" +
+				"|          2 |       4 |        4 |\n" +
+				"+------------+---------+----------+\n" +
+				"| THROUGHPUT | AVERAGE | AVERAGE  |\n" +
+				"|   0.25/t   |  2.00   |   2.00   |\n" +
+				"+------------+---------+----------+\n",
+		},
+		{
+			name: "time unit",
+			opts: outputOptions{columns: idWaitResponse, numbers: numberFormat{Label: "ms"}},
+			wantOut: "--------\n   FCFS\n--------\n" +
+				"Gantt schedule\n|    1     |  2   |\n0          5      8\n\n" +
+				"Schedule table\n" +
+				"+------------+---------+----------+\n" +
+				"|     ID     |  WAIT   | RESPONSE |\n" +
+				"+------------+---------+----------+\n" +
+				"|          1 |       0 |        0 |\n" +
+				"|          2 |       4 |        4 |\n" +
+				"+------------+---------+----------+\n" +
+				"| THROUGHPUT | AVERAGE | AVERAGE  |\n" +
+				"|  0.25/ms   |  2.00   |   2.00   |\n" +
+				"+------------+---------+----------+\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputResult(&w, "FCFS", r, tt.opts)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("outputResult() = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_ganttCells(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		nf    numberFormat
+		want  []ganttCell
+	}{
+		{
+			name:  "idle slice",
+			gantt: []TimeSlice{{Start: 0, Stop: 2, Idle: true}, {PID: 1, Start: 2, Stop: 3}},
+			want: []ganttCell{
+				{TimeSlice: TimeSlice{Start: 0, Stop: 2, Idle: true}, width: 4},
+				{TimeSlice: TimeSlice{PID: 1, Start: 2, Stop: 3}, width: 2},
+			},
+		},
+		{
+			name:  "overhead gap",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 5}},
+			want: []ganttCell{
+				{TimeSlice: TimeSlice{PID: 1, Start: 0, Stop: 2}, width: 4},
+				{TimeSlice: TimeSlice{Start: 2, Stop: 4}, gap: true, width: 4},
+				{TimeSlice: TimeSlice{PID: 2, Start: 4, Stop: 5}, width: 2},
+			},
+		},
+		{
+			name:  "scaled to fit",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 100}, {PID: 2, Start: 100, Stop: 400}},
+			want: []ganttCell{
+				{TimeSlice: TimeSlice{PID: 1, Start: 0, Stop: 100}, width: 20},
+				{TimeSlice: TimeSlice{PID: 2, Start: 100, Stop: 400}, width: 60},
+			},
+		},
+		{
+			name:  "wide enough for the time",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1000, Stop: 1001}},
+			want: []ganttCell{
+				{TimeSlice: TimeSlice{PID: 1, Start: 0, Stop: 1}, width: 1},
+				{TimeSlice: TimeSlice{Start: 1, Stop: 1000}, gap: true, width: 80},
+				{TimeSlice: TimeSlice{PID: 2, Start: 1000, Stop: 1001}, width: 4},
+			},
+		},
+		{
+			name:  "finer resolution",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 25}},
+			nf:    numberFormat{Resolution: 10},
+			want:  []ganttCell{{TimeSlice: TimeSlice{PID: 1, Start: 0, Stop: 25}, width: 5}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ganttCells(tt.gantt, tt.nf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ganttCells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputGantt_idle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      string
+	}{
+		{
+			name: "between processes",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 5},
+			},
+			want: "Gantt schedule\n| 1  | IDLE |2 |\n0    2      5  6\n\n" +
+				"CPU idle: 3 of 6 (50.00%)\n\n",
+		},
+		{
+			name: "before the first arrival",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, ArrivalTime: 3},
+			},
+			want: "Gantt schedule\n| IDLE | 1  |\n0      3    5\n\n" +
+				"CPU idle: 3 of 5 (60.00%)\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.processes, &fcfsPolicy{}, simOptions{})
+			var w bytes.Buffer
+			outputResult(&w, "FCFS", r, outputOptions{})
+			want := "--------\n   FCFS\n--------\n" + tt.want
+			if got := w.String(); !strings.HasPrefix(got, want) {
+				t.Errorf("outputResult() = %q, want it to start with %q", got, want)
+			}
+		})
+	}
+}
+
+func Test_ganttLabel(t *testing.T) {
+	t.Parallel()
+	names := map[int64]string{1: "editor", 2: "compilation"}
+	tests := []struct {
+		pid   int64
+		width int
+		want  string
+	}{
+		{pid: 1, width: 8, want: "editor"},
+		{pid: 2, width: 8, want: "compila…"},
+		{pid: 3, width: 8, want: "3"},
+		{pid: 1, width: 3, want: "ed…"},
+		{pid: 33, width: 1, want: "…"},
+	}
+	for _, tt := range tests {
+		if got := ganttLabel(tt.pid, names, tt.width); got != tt.want {
+			t.Errorf("ganttLabel(%d, %d) = %q, want %q", tt.pid, tt.width, got, tt.want)
+		}
+	}
+}
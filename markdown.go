@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// outputMarkdown writes a run's results as GitHub-flavored Markdown, to be
+// dropped into READMEs, issues and write-ups: per algorithm a heading, the
+// Gantt chart in a fenced code block and the schedule table, with its
+// averages in a closing bold row, then a comparison table when more than
+// one algorithm ran.
+func outputMarkdown(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	opts := cfg.outputOptions()
+	opts.color = false
+	nf := opts.numbers
+
+	for i, row := range summary {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		r := row.Result
+		var gantt bytes.Buffer
+		outputGantt(&gantt, clipGantt(r.Gantt, opts.from, opts.to), processNames(r.Schedule), opts)
+		_, _ = fmt.Fprintf(w, "## %s\n\n```\n%s\n```\n\n", row.Scheduler.Title, strings.TrimRight(gantt.String(), "\n"))
+
+		columns := opts.columns
+		if columns == nil {
+			columns = defaultColumnsFor(r.Schedule)
+		}
+		rows := opts.sortBy.sort(r.Schedule)
+		if opts.maxRows > 0 && len(rows) > opts.maxRows {
+			rows = rows[:opts.maxRows]
+		}
+		header := make([]string, len(columns))
+		averages := make([]string, len(columns))
+		for j, c := range columns {
+			header[j] = nf.header(c.Header)
+			if c.Average != nil {
+				averages[j] = "**" + nf.duration(c.Average(r)) + "**"
+			}
+		}
+		if averages[0] == "" {
+			averages[0] = "**" + nf.header("Average") + "**"
+		}
+		cells := [][]string{header}
+		for _, p := range rows {
+			line := make([]string, len(columns))
+			for j, c := range columns {
+				line[j] = c.format(p, nf)
+			}
+			cells = append(cells, line)
+		}
+		writeMarkdownTable(w, append(cells, averages))
+		_, _ = fmt.Fprintf(w, "\n%s: %s\n", nf.header("Throughput"), nf.rate(r.AveThroughput))
+		for _, line := range customMetricLines(r, metricPlugins, nf) {
+			_, _ = fmt.Fprintf(w, "%s\n", line)
+		}
+	}
+
+	if len(summary) > 1 {
+		cells, best := summaryCells(summary, nf)
+		for r := 1; r < len(cells); r++ {
+			for c := 1; c < len(cells[r]); c++ {
+				if best[r][c] {
+					cells[r][c] = "**" + cells[r][c] + "**"
+				}
+			}
+		}
+		_, _ = fmt.Fprint(w, "\n## Comparison\n\n")
+		writeMarkdownTable(w, cells)
+	}
+	return nil
+}
+
+// writeMarkdownTable writes cells, header row first, as a GFM table with
+// every column but the first right-aligned.
+func writeMarkdownTable(w io.Writer, cells [][]string) {
+	var b bytes.Buffer
+	for i, row := range cells {
+		b.WriteString("|")
+		for _, cell := range row {
+			b.WriteString(" " + strings.ReplaceAll(cell, "|", `\|`) + " |")
+		}
+		b.WriteString("\n")
+		if i == 0 {
+			b.WriteString("|")
+			for c := range row {
+				if c == 0 {
+					b.WriteString(" --- |")
+				} else {
+					b.WriteString(" ---: |")
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, _ = w.Write(b.Bytes())
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputMarkdown(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, Name: "a|b", BurstDuration: 3, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs", "priority"}
+	cfg.Format = "md"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+	want := "## First-come, first-serve\n\n" +
+		"```\nGantt schedule\n| a|b  |2 |\n0      3  4\n```\n\n" +
+		"| ID | Name | Priority | Burst | Arrival | Wait | Turnaround | Exit |\n" +
+		"| --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: |\n" +
+		"| 1 | a\\|b | 0 | 3 | 0 | 0 | 3 | 3 |\n" +
+		"| 2 | - | 0 | 1 | 1 | 2 | 3 | 4 |\n" +
+		"| **Average** |  |  |  |  | **1.00** | **3.00** |  |\n" +
+		"\nThroughput: 0.50/t\n" +
+		"\n## Priority\n\n" +
+		"```\nGantt schedule\n| a|b  |2 |\n0      3  4\n```\n\n" +
+		"| ID | Name | Priority | Burst | Arrival | Wait | Turnaround | Exit |\n" +
+		"| --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: |\n" +
+		"| 1 | a\\|b | 0 | 3 | 0 | 0 | 3 | 3 |\n" +
+		"| 2 | - | 0 | 1 | 1 | 2 | 3 | 4 |\n" +
+		"| **Average** |  |  |  |  | **1.00** | **3.00** |  |\n" +
+		"\nThroughput: 0.50/t\n" +
+		"\n## Comparison\n\n" +
+		"| Algorithm | Avg wait | Avg turnaround | Throughput | Context switches | Makespan |\n" +
+		"| --- | ---: | ---: | ---: | ---: | ---: |\n" +
+		"| fcfs | **1.00** | **3.00** | **0.50/t** | **1** | **4** |\n" +
+		"| priority | **1.00** | **3.00** | **0.50/t** | **1** | **4** |\n"
+	if got := w.String(); got != want {
+		t.Errorf("run() =\n%s\nwant\n%s", got, want)
+	}
+}
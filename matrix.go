@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Cells of the timeline matrix.
+const (
+	matrixRunning = 'R'
+	matrixWaiting = 'W'
+	matrixBlocked = 'B'
+	matrixAbsent  = '.'
+)
+
+// matrixLabelWidth is as much of a process's name as the matrix shows.
+const matrixLabelWidth = 8
+
+// outputMatrix prints r as a matrix of processes by ticks, the way
+// textbooks work examples: each cell shows whether the process was running,
+// waiting in the ready queue or blocked on I/O during that tick, with dots
+// before it arrived and after it exited. Times are marked along the top
+// every few ticks, wide enough apart for their labels.
+func outputMatrix(w io.Writer, r Result, opts outputOptions) {
+	if len(r.Schedule) == 0 {
+		return
+	}
+	from, to := opts.from, opts.to
+	if to == 0 {
+		for _, p := range r.Schedule {
+			to = max(to, p.Exit)
+		}
+	}
+	if to <= from {
+		return
+	}
+
+	rows := make(map[int64][]byte, len(r.Schedule))
+	for _, p := range r.Schedule {
+		row := make([]byte, to-from)
+		for t := range row {
+			if at := from + int64(t); at >= p.Arrival && at < p.Exit {
+				row[t] = matrixWaiting
+			} else {
+				row[t] = matrixAbsent
+			}
+		}
+		rows[p.ProcessID] = row
+	}
+	mark := func(spans []TimeSlice, state byte) {
+		for _, s := range spans {
+			if s.Idle {
+				continue
+			}
+			for t := max(s.Start, from); t < min(s.Stop, to); t++ {
+				rows[s.PID][t-from] = state
+			}
+		}
+	}
+	mark(r.Blocked, matrixBlocked)
+	mark(r.Gantt, matrixRunning)
+
+	names := processNames(r.Schedule)
+	labels := make([]string, len(r.Schedule))
+	width := 0
+	for i, p := range r.Schedule {
+		labels[i] = ganttLabel(p.ProcessID, names, matrixLabelWidth)
+		width = max(width, utf8.RuneCountInString(labels[i]))
+	}
+
+	step := int64(5)
+	for int64(utf8.RuneCountInString(opts.numbers.at(to))) >= step {
+		step += 5
+	}
+	var axis strings.Builder
+	for t := from; t < to; t += step {
+		at := opts.numbers.at(t)
+		axis.WriteString(at + strings.Repeat(" ", max(int(min(step, to-t))-utf8.RuneCountInString(at), 0)))
+	}
+
+	_, _ = fmt.Fprintf(w, "Timeline (%c running, %c waiting, %c blocked)\n", matrixRunning, matrixWaiting, matrixBlocked)
+	_, _ = fmt.Fprintf(w, "%*s %s\n", width, "", strings.TrimRight(axis.String(), " "))
+	for i, p := range r.Schedule {
+		_, _ = fmt.Fprintf(w, "%-*s %s\n", width, labels[i], rows[p.ProcessID])
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputMatrix(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 3, Bursts: []Burst{{Duration: 2}, {Duration: 3, IO: true}, {Duration: 1}}},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+	}
+	r := simulate(processes, &fcfsPolicy{}, simOptions{})
+	tests := []struct {
+		name string
+		opts outputOptions
+		want string
+	}{
+		{
+			name: "whole run",
+			want: "Timeline (R running, W waiting, B blocked)\n" +
+				"       0    5\n" +
+				"editor RRBBBR\n" +
+				"2      .WRR..\n\n",
+		},
+		{
+			name: "window",
+			opts: outputOptions{from: 1, to: 4},
+			want: "Timeline (R running, W waiting, B blocked)\n" +
+				"       1\n" +
+				"editor RBB\n" +
+				"2      WRR\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputMatrix(&w, r, tt.opts)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputMatrix() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// mergeWorkloads loads several workload files into one simulation. Each
+// file's PIDs are put in a namespace of their own, so files may reuse
+// them: the nth file's PIDs become n*stride + pid, stride being the
+// smallest power of ten above every PID, so process 3 of the second file
+// is 203 when the largest PID is below 100. Dependencies are renumbered
+// with them. Processes without an owner take their file's name, so the
+// per-owner breakdown tells the files apart. The merged processes are put
+// in arrival order, ties in the order the files were given.
+func mergeWorkloads(paths []string, opts loadOptions) ([]Process, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: --merge needs workload files to merge", ErrInvalidArgs)
+	}
+	workloads := make([][]Process, len(paths))
+	var largest int64
+	for i, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v: error opening scheduling file", ErrInvalidArgs, err)
+		}
+		workloads[i], err = loadWorkload(path, f, opts)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range workloads[i] {
+			largest = max(largest, p.ProcessID)
+		}
+	}
+
+	stride := int64(10)
+	for stride <= largest {
+		if stride > math.MaxInt64/10 {
+			return nil, fmt.Errorf("%w: PIDs are too large to merge", ErrInvalidWorkload)
+		}
+		stride *= 10
+	}
+	if int64(len(paths)) > (math.MaxInt64-largest)/stride {
+		return nil, fmt.Errorf("%w: PIDs are too large to merge %d files", ErrInvalidWorkload, len(paths))
+	}
+
+	var merged []Process
+	for i, processes := range workloads {
+		namespace := int64(i+1) * stride
+		owner := strings.TrimSuffix(filepath.Base(paths[i]), filepath.Ext(paths[i]))
+		for _, p := range processes {
+			p.ProcessID += namespace
+			if len(p.DependsOn) > 0 {
+				deps := make([]int64, len(p.DependsOn))
+				for j, dep := range p.DependsOn {
+					deps[j] = dep + namespace
+				}
+				p.DependsOn = deps
+			}
+			if p.Owner == "" {
+				p.Owner = owner
+			}
+			merged = append(merged, p)
+		}
+	}
+	slices.SortStableFunc(merged, func(a, b Process) int {
+		return cmp.Compare(a.ArrivalTime, b.ArrivalTime)
+	})
+	return merged, nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func Test_mergeWorkloads(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeWorkload := func(name, content string) string {
+		p := path.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	alice := writeWorkload("alice.csv", "pid,burst,arrival,depends_on\n1,3,0,\n2,2,1,1\n")
+	bob := writeWorkload("bob.yaml", "processes:\n  - {pid: 1, burst: 4, arrival: 0, owner: robert}\n")
+	large := writeWorkload("large.csv", "150,1,2\n")
+	broken := writeWorkload("broken.csv", "1,x,0\n")
+
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []Process
+		wantErr error
+	}{
+		{
+			name:  "namespaced by file",
+			paths: []string{alice, bob},
+			want: []Process{
+				{ProcessID: 11, Owner: "alice", BurstDuration: 3},
+				{ProcessID: 21, Owner: "robert", BurstDuration: 4},
+				{ProcessID: 12, Owner: "alice", BurstDuration: 2, ArrivalTime: 1, DependsOn: []int64{11}},
+			},
+		},
+		{
+			name:  "stride grows with the largest PID",
+			paths: []string{large, bob},
+			want: []Process{
+				{ProcessID: 2001, Owner: "robert", BurstDuration: 4},
+				{ProcessID: 1150, Owner: "large", BurstDuration: 1, ArrivalTime: 2},
+			},
+		},
+		{name: "no files", wantErr: ErrInvalidArgs},
+		{name: "missing file", paths: []string{alice, path.Join(dir, "missing.csv")}, wantErr: ErrInvalidArgs},
+		{name: "invalid workload", paths: []string{alice, broken}, wantErr: ErrInvalidWorkload},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := mergeWorkloads(tt.paths, loadOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mergeWorkloads() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeWorkloads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
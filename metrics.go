@@ -0,0 +1,119 @@
+package main
+
+// Metric is a custom measurement computed from a simulation's events, for
+// metrics too specialized to build in. Registered metrics are collected in
+// every simulation and appear as extra columns wherever the algorithms are
+// compared (--compare, --summary-image, generate --repeat and the metrics
+// sheets of the csv and xlsx formats) and under each schedule table.
+type Metric struct {
+	// Name keys the value in Result.Metrics and heads its column.
+	Name string
+	// New returns a collector for one simulation run.
+	New func() MetricCollector
+	// Format renders a value; nil prints it with two decimals.
+	Format func(v float64, nf numberFormat) string
+	// HigherIsBetter flips which value the comparison marks as best.
+	HigherIsBetter bool
+}
+
+// MetricCollector observes one run through its hooks and reports a single
+// value once the run has finished.
+type MetricCollector interface {
+	Hooks() Hooks
+	Value(r Result) float64
+}
+
+// metricPlugins holds the registered metrics, in registration order.
+var metricPlugins []Metric
+
+// RegisterMetric adds a custom metric to every later simulation. It is
+// meant to be called from an init function in a file added to this
+// package, before any simulation runs.
+//
+//	func init() {
+//		RegisterMetric(Metric{Name: "Preemptions", New: newPreemptionCounter})
+//	}
+func RegisterMetric(m Metric) {
+	metricPlugins = append(metricPlugins, m)
+	name := m.Name
+	summaryMetrics = append(summaryMetrics, summaryMetric{
+		Header:         name,
+		Value:          func(r Result) float64 { return r.Metrics[name] },
+		Format:         m.format,
+		HigherIsBetter: m.HigherIsBetter,
+	})
+}
+
+func (m Metric) format(v float64, nf numberFormat) string {
+	if m.Format == nil {
+		return nf.float(v)
+	}
+	return m.Format(v, nf)
+}
+
+// customMetricLines lists r's values of metrics as "name: value" lines, in
+// order, leaving out those the run did not collect, such as a replayed one.
+func customMetricLines(r Result, metrics []Metric, nf numberFormat) []string {
+	var lines []string
+	for _, m := range metrics {
+		if v, ok := r.Metrics[m.Name]; ok {
+			lines = append(lines, nf.header(m.Name)+": "+m.format(v, nf))
+		}
+	}
+	return lines
+}
+
+// chainHooks combines hooks, calling each set's callbacks in order and
+// skipping those left nil.
+func chainHooks(hooks ...Hooks) Hooks {
+	var chained Hooks
+	for _, h := range hooks {
+		h := h
+		if h.OnDispatch != nil {
+			prev := chained.OnDispatch
+			chained.OnDispatch = func(now, pid int64) {
+				if prev != nil {
+					prev(now, pid)
+				}
+				h.OnDispatch(now, pid)
+			}
+		}
+		if h.OnPreempt != nil {
+			prev := chained.OnPreempt
+			chained.OnPreempt = func(now, pid, next int64) {
+				if prev != nil {
+					prev(now, pid, next)
+				}
+				h.OnPreempt(now, pid, next)
+			}
+		}
+		if h.OnComplete != nil {
+			prev := chained.OnComplete
+			chained.OnComplete = func(now, pid int64) {
+				if prev != nil {
+					prev(now, pid)
+				}
+				h.OnComplete(now, pid)
+			}
+		}
+		if h.OnBlock != nil {
+			prev := chained.OnBlock
+			chained.OnBlock = func(now, pid, wake int64) {
+				if prev != nil {
+					prev(now, pid, wake)
+				}
+				h.OnBlock(now, pid, wake)
+			}
+		}
+		if h.OnIdle != nil {
+			prev := chained.OnIdle
+			chained.OnIdle = func(from, to int64) {
+				if prev != nil {
+					prev(from, to)
+				}
+				h.OnIdle(from, to)
+			}
+		}
+	}
+	return chained
+}
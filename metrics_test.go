@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// preemptionCounter is a sample custom metric: how often a process lost
+// the CPU before completing.
+type preemptionCounter struct{ n int }
+
+func (c *preemptionCounter) Hooks() Hooks {
+	return Hooks{OnPreempt: func(now, pid, next int64) { c.n++ }}
+}
+
+func (c *preemptionCounter) Value(Result) float64 { return float64(c.n) }
+
+// idleShare is a sample custom metric combining events with the result.
+type idleShare struct{ idle int64 }
+
+func (c *idleShare) Hooks() Hooks {
+	return Hooks{OnIdle: func(from, to int64) { c.idle += to - from }}
+}
+
+func (c *idleShare) Value(r Result) float64 { return float64(c.idle) / float64(r.Makespan) }
+
+func Test_simulate_metrics(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+		{ProcessID: 4, ArrivalTime: 25, BurstDuration: 5, Priority: 1},
+	}
+	metrics := []Metric{
+		{Name: "Preemptions", New: func() MetricCollector { return &preemptionCounter{} }},
+		{Name: "Idle share", New: func() MetricCollector { return &idleShare{} }},
+	}
+
+	var dispatches int
+	opts := simOptions{metrics: metrics, hooks: Hooks{OnDispatch: func(now, pid int64) { dispatches++ }}}
+	got := simulate(processes, newSJFPolicy(defaultRunQueue), opts)
+
+	want := map[string]float64{"Preemptions": 1, "Idle share": 5.0 / 30}
+	if !reflect.DeepEqual(got.Metrics, want) {
+		t.Errorf("simulate() metrics = %v, want %v", got.Metrics, want)
+	}
+	if dispatches != 5 {
+		t.Errorf("simulate() with metrics called OnDispatch %d times, want 5", dispatches)
+	}
+	if again := simulate(processes, newSJFPolicy(defaultRunQueue), opts); !reflect.DeepEqual(again.Metrics, want) {
+		t.Errorf("simulate() metrics on a second run = %v, want fresh collectors giving %v", again.Metrics, want)
+	}
+}
+
+func Test_chainHooks(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	record := func(name string) Hooks {
+		return Hooks{
+			OnComplete: func(now, pid int64) { calls = append(calls, name) },
+		}
+	}
+	h := chainHooks(record("a"), Hooks{}, record("b"))
+	if h.OnDispatch != nil || h.OnPreempt != nil || h.OnIdle != nil {
+		t.Errorf("chainHooks() set callbacks no hook gave")
+	}
+	h.OnComplete(1, 1)
+	if want := []string{"a", "b"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("chainHooks() called %v, want %v", calls, want)
+	}
+}
+
+func Test_metricsRecords_custom(t *testing.T) {
+	t.Parallel()
+	metrics := []Metric{
+		{Name: "Preemptions", Format: formatCount},
+		{Name: "Idle share"},
+	}
+	summary := []summaryRow{
+		{Scheduler: Scheduler{Name: "sjf"}, Result: Result{Metrics: map[string]float64{"Preemptions": 3, "Idle share": 0.25}}},
+		{Scheduler: Scheduler{Name: "replayed"}},
+	}
+	records := metricsRecords(summary, metrics, numberFormat{})
+	got := [][]string{records[0][7:], records[1][7:], records[2][7:]}
+	want := [][]string{{"Preemptions", "Idle share"}, {"3", "0.25"}, {"", ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("metricsRecords() custom columns = %v, want %v", got, want)
+	}
+
+	lines := customMetricLines(summary[0].Result, metrics, numberFormat{})
+	if want := []string{"Preemptions: 3", "Idle share: 0.25"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("customMetricLines() = %v, want %v", lines, want)
+	}
+	if lines := customMetricLines(summary[1].Result, metrics, numberFormat{}); lines != nil {
+		t.Errorf("customMetricLines() of a run without metrics = %v, want none", lines)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"slices"
+)
+
+// minimizePredicate reports, as an ErrAssertion, the failure a workload is
+// being minimized for.
+type minimizePredicate func(cfg Config, processes []Process) error
+
+var minimizePredicates = map[string]minimizePredicate{
+	// invariants fails when any selected algorithm produces an invalid
+	// schedule.
+	"invariants": func(cfg Config, processes []Process) error {
+		for _, name := range cfg.Algorithms {
+			s, _ := lookupScheduler(name)
+			if err := checkInvariants(processes, s.Schedule(processes, cfg)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		return nil
+	},
+	// run-queues fails when any run queue implementation changes the
+	// result of an algorithm built on one.
+	"run-queues": func(cfg Config, processes []Process) error {
+		for _, name := range cfg.Algorithms {
+			s, _ := lookupScheduler(name)
+			if !slices.Contains(s.Params, "run_queue") {
+				continue
+			}
+			c := cfg
+			c.RunQueue = runQueueNames[0]
+			want := s.Schedule(processes, c)
+			for _, queue := range runQueueNames[1:] {
+				c.RunQueue = queue
+				if got := s.Schedule(processes, c); !reflect.DeepEqual(got, want) {
+					return fmt.Errorf("%w: %s results differ between the %s and %s run queues", ErrAssertion, name, runQueueNames[0], queue)
+				}
+			}
+		}
+		return nil
+	},
+}
+
+// execPredicate fails when command, run by the shell with a candidate
+// workload CSV as $1, exits non-zero.
+func execPredicate(command string) minimizePredicate {
+	return func(_ Config, processes []Process) error {
+		f, err := os.CreateTemp("", "minimize-*.csv")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		if err := writeProcesses(f, processes); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if err := exec.Command("sh", "-c", command, "minimize", f.Name()).Run(); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrAssertion, command, err)
+		}
+		return nil
+	}
+}
+
+// ddmin delta-debugs processes down to a subset for which fails still
+// holds and from which no single process can be removed. It tries keeping
+// each of n chunks, then dropping each, doubling n whenever neither
+// helps. It returns the subset and how many times fails was called.
+func ddmin(processes []Process, fails func([]Process) bool) ([]Process, int) {
+	var (
+		current = processes
+		n       = 2
+		tests   int
+	)
+	try := func(candidate []Process) bool {
+		tests++
+		return fails(candidate)
+	}
+	for len(current) >= 2 {
+		chunks := splitChunks(current, n)
+		reduced := false
+		for _, chunk := range chunks {
+			if try(chunk) {
+				current, n, reduced = chunk, 2, true
+				break
+			}
+		}
+		if !reduced && n > 2 {
+			for i := range chunks {
+				complement := make([]Process, 0, len(current)-len(chunks[i]))
+				for j, chunk := range chunks {
+					if j != i {
+						complement = append(complement, chunk...)
+					}
+				}
+				if try(complement) {
+					current, n, reduced = complement, max(n-1, 2), true
+					break
+				}
+			}
+		}
+		if !reduced {
+			if n >= len(current) {
+				break
+			}
+			n = min(2*n, len(current))
+		}
+	}
+	return current, tests
+}
+
+// splitChunks splits processes into n contiguous chunks of nearly equal
+// size.
+func splitChunks(processes []Process, n int) [][]Process {
+	chunks := make([][]Process, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(processes)-start)/(n-i)
+		chunks = append(chunks, processes[start:end])
+		start = end
+	}
+	return chunks
+}
+
+// minimize shrinks a workload that triggers a failure to a minimal set of
+// processes that still triggers it, and writes that set as CSV. The
+// failure is a built-in --predicate or a shell command given with --exec.
+// The workload is loaded and simulated under the flags of a regular run,
+// so --seed, --on-duplicate and the like reproduce the failing one.
+func minimize(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"minimize"}, args...)...)
+	if err != nil {
+		return err
+	}
+	if cfg.Predicate == "" {
+		cfg.Predicate = "invariants"
+	}
+	predicate, ok := minimizePredicates[cfg.Predicate]
+	if !ok {
+		return fmt.Errorf("%w: unknown predicate %q, want invariants or run-queues", ErrInvalidArgs, cfg.Predicate)
+	}
+	if cfg.Exec != "" {
+		predicate = execPredicate(cfg.Exec)
+	}
+
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+	processes, err := loadWorkload(f.Name(), f, cfg.loadOptions())
+	if err != nil {
+		return err
+	}
+
+	failure := predicate(cfg, processes)
+	if failure == nil {
+		return fmt.Errorf("%w: %s does not fail the predicate, nothing to minimize", ErrInvalidArgs, f.Name())
+	}
+	if !errors.Is(failure, ErrAssertion) {
+		return failure
+	}
+	smallest, tests := ddmin(processes, func(candidate []Process) bool {
+		return errors.Is(predicate(cfg, candidate), ErrAssertion)
+	})
+	_, _ = fmt.Fprintf(os.Stderr, "minimized %d processes to %d in %d tests: %v\n", len(processes), len(smallest), tests, predicate(cfg, smallest))
+	return writeProcesses(w, smallest)
+}
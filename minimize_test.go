@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_ddmin(t *testing.T) {
+	t.Parallel()
+	processes := generateProcesses(rand.New(rand.NewSource(2)), generatorSpec{Count: 40, MaxArrival: 50, MaxBurst: 5, MaxPriority: 5})
+	// Fails whenever processes 7 and 31 are both present.
+	fails := func(candidate []Process) bool {
+		var seven, thirtyOne bool
+		for _, p := range candidate {
+			seven = seven || p.ProcessID == 7
+			thirtyOne = thirtyOne || p.ProcessID == 31
+		}
+		return seven && thirtyOne
+	}
+	got, tests := ddmin(processes, fails)
+	if want := []Process{processes[6], processes[30]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ddmin() = %v, want %v", got, want)
+	}
+	if tests > 100 {
+		t.Errorf("ddmin() took %d tests, want far fewer than trying every subset", tests)
+	}
+}
+
+func Test_minimize(t *testing.T) {
+	t.Parallel()
+	workload := filepath.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(workload, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n4,2,7,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "exec predicate",
+			args: []string{"-exec", `! grep -q '^3,' "$1"`, workload},
+			want: "3,6,6,3\n",
+		},
+		{
+			name: "regular run flags",
+			args: []string{"--on-duplicate", "renumber", "--sched-overhead", "1", "-exec", `! grep -q '^3,' "$1"`, workload},
+			want: "3,6,6,3\n",
+		},
+		{
+			name:    "workload does not fail",
+			args:    []string{"-predicate", "invariants", workload},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown predicate",
+			args:    []string{"-predicate", "flaky", workload},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := minimize(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("minimize() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("minimize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ndjsonEvent is one line of --format ndjson output: a scheduling event,
+// written the moment the simulation reaches it, so arbitrarily long runs
+// can be processed as they go. Fields that do not apply to the event are
+// left out.
+//
+//	{"algorithm":"rr","event":"dispatch","time":0,"pid":1}
+//	{"algorithm":"rr","event":"preempt","time":2,"pid":1,"next":2}
+//	{"algorithm":"rr","event":"block","time":4,"pid":2,"wake":7}
+//	{"algorithm":"rr","event":"idle","time":9,"until":12}
+//	{"algorithm":"rr","event":"complete","time":14,"pid":1}
+type ndjsonEvent struct {
+	Algorithm string `json:"algorithm"`
+	Event     string `json:"event"`
+	Time      int64  `json:"time"`
+	PID       *int64 `json:"pid,omitempty"`
+	Next      *int64 `json:"next,omitempty"`
+	Wake      *int64 `json:"wake,omitempty"`
+	Until     *int64 `json:"until,omitempty"`
+}
+
+// ndjsonHooks writes each of an algorithm's scheduling events to w as a
+// line of JSON.
+func ndjsonHooks(w io.Writer, algorithm string) Hooks {
+	enc := json.NewEncoder(w)
+	emit := func(e ndjsonEvent) {
+		e.Algorithm = algorithm
+		_ = enc.Encode(e)
+	}
+	return Hooks{
+		OnDispatch: func(now, pid int64) {
+			emit(ndjsonEvent{Event: "dispatch", Time: now, PID: &pid})
+		},
+		OnPreempt: func(now, pid, next int64) {
+			emit(ndjsonEvent{Event: "preempt", Time: now, PID: &pid, Next: &next})
+		},
+		OnComplete: func(now, pid int64) {
+			emit(ndjsonEvent{Event: "complete", Time: now, PID: &pid})
+		},
+		OnBlock: func(now, pid, wake int64) {
+			emit(ndjsonEvent{Event: "block", Time: now, PID: &pid, Wake: &wake})
+		},
+		OnIdle: func(from, to int64) {
+			emit(ndjsonEvent{Event: "idle", Time: from, Until: &to})
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_ndjsonHooks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 5},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"rr"}
+	cfg.Format = "ndjson"
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		`{"algorithm":"rr","event":"dispatch","time":0,"pid":1}`,
+		`{"algorithm":"rr","event":"complete","time":3,"pid":1}`,
+		`{"algorithm":"rr","event":"idle","time":3,"until":5}`,
+		`{"algorithm":"rr","event":"dispatch","time":5,"pid":2}`,
+		`{"algorithm":"rr","event":"complete","time":6,"pid":2}`,
+	}, "\n") + "\n"
+	if got := w.String(); got != want {
+		t.Errorf("run() = %s, want %s", got, want)
+	}
+}
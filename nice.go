@@ -0,0 +1,54 @@
+package main
+
+import "fmt"
+
+// Nice values range from -20, the most favored, to 19, as on Unix.
+const (
+	minNice = -20
+	maxNice = 19
+)
+
+// niceWeights is the kernel's sched_prio_to_weight table: the CFS load
+// weight of each nice value, indexed by nice+20. Each step is about 1.25
+// times the next, so one nice level is worth roughly 10% of CPU time.
+var niceWeights = [maxNice - minNice + 1]int64{
+	88761, 71755, 56483, 46273, 36291,
+	29154, 23254, 18705, 14949, 11916,
+	9548, 7620, 6100, 4904, 3906,
+	3121, 2501, 1991, 1586, 1277,
+	1024, 820, 655, 526, 423,
+	335, 272, 215, 172, 137,
+	110, 87, 70, 56, 45,
+	36, 29, 23, 18, 15,
+}
+
+// withNice gives p a nice value. Its priority becomes nice+20, from 0 for
+// the most favored to 39, in the order of the kernel's static priorities,
+// and its weight the CFS weight of the nice value. A priority given as
+// well must agree.
+func withNice(p Process, nice int64, priorityGiven bool) (Process, error) {
+	if nice < minNice || nice > maxNice {
+		return p, fmt.Errorf("nice must be from %d to %d, got %d", minNice, maxNice, nice)
+	}
+	priority := nice - minNice
+	if priorityGiven && p.Priority != priority {
+		return p, fmt.Errorf("priority %d does not match nice %d, which maps to priority %d", p.Priority, nice, priority)
+	}
+	p.Nice = nice
+	p.Priority = priority
+	p.Weight = niceWeights[priority]
+	return p, nil
+}
+
+// withWeight gives p a weight, or number of lottery tickets, which must be
+// positive. A weight its nice value gave it must agree.
+func withWeight(p Process, weight int64) (Process, error) {
+	if weight <= 0 {
+		return p, fmt.Errorf("weight must be positive, got %d", weight)
+	}
+	if p.Weight != 0 && p.Weight != weight {
+		return p, fmt.Errorf("weight %d does not match nice %d, whose weight is %d", weight, p.Nice, p.Weight)
+	}
+	p.Weight = weight
+	return p, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func Test_withNice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		p             Process
+		nice          int64
+		priorityGiven bool
+		wantPriority  int64
+		wantWeight    int64
+		wantErr       bool
+	}{
+		{name: "most favored", nice: -20, wantPriority: 0, wantWeight: 88761},
+		{name: "default", nice: 0, wantPriority: 20, wantWeight: 1024},
+		{name: "least favored", nice: 19, wantPriority: 39, wantWeight: 15},
+		{name: "agreeing priority", p: Process{Priority: 25}, nice: 5, priorityGiven: true, wantPriority: 25, wantWeight: 335},
+		{name: "disagreeing priority", p: Process{Priority: 3}, nice: 5, priorityGiven: true, wantErr: true},
+		{name: "out of range", nice: 20, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := withNice(tt.p, tt.nice, tt.priorityGiven)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("withNice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Priority != tt.wantPriority || got.Weight != tt.wantWeight || got.Nice != tt.nice {
+				t.Errorf("withNice() = nice %d, priority %d, weight %d, want nice %d, priority %d, weight %d",
+					got.Nice, got.Priority, got.Weight, tt.nice, tt.wantPriority, tt.wantWeight)
+			}
+		})
+	}
+}
+
+func Test_withWeight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		p       Process
+		weight  int64
+		want    int64
+		wantErr bool
+	}{
+		{name: "tickets", weight: 100, want: 100},
+		{name: "agreeing nice", p: Process{Nice: 5, Weight: 335}, weight: 335, want: 335},
+		{name: "disagreeing nice", p: Process{Nice: 5, Weight: 335}, weight: 100, wantErr: true},
+		{name: "zero", weight: 0, wantErr: true},
+		{name: "negative", weight: -3, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := withWeight(tt.p, tt.weight)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("withWeight() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Weight != tt.want {
+				t.Errorf("withWeight() weight = %d, want %d", got.Weight, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// numberFormat controls how numbers and headers are rendered. Each tick
+// is worth Scale units of Label, so a trace recorded in microseconds can
+// be shown in milliseconds with Label "ms" and Scale 0.001. A printer, when
+// set, localizes decimal separators and digit grouping, and headers are
+// translated when a dictionary is set. The zero value renders plain ticks
+// as "t" with Go's own number formatting. The simulation itself always
+// runs in whole ticks, Resolution of them to a unit, so at a resolution of
+// 10 a 25-tick burst renders as 2.5. With an Epoch, points in time are
+// instead shown as the wall-clock time Tick-long units after it.
+type numberFormat struct {
+	Label      string
+	Scale      float64
+	Resolution int64
+	Epoch      time.Time
+	Tick       time.Duration
+	printer    *message.Printer
+	headers    map[string]string
+}
+
+func (nf numberFormat) label() string {
+	if nf.Label == "" {
+		return "t"
+	}
+	return nf.Label
+}
+
+func (nf numberFormat) scale() float64 {
+	if nf.Scale == 0 {
+		return 1
+	}
+	return nf.Scale
+}
+
+// units converts a tick count to time units.
+func (nf numberFormat) units(ticks float64) float64 {
+	if nf.Resolution > 1 {
+		ticks /= float64(nf.Resolution)
+	}
+	return ticks * nf.scale()
+}
+
+// timeUnitLabels maps the --time-unit values onto their printed labels.
+// The empty default keeps the historic unitless "t".
+var timeUnitLabels = map[string]string{
+	"":      "",
+	"ticks": "tick",
+	"ms":    "ms",
+	"s":     "s",
+}
+
+func (c Config) numberFormat() numberFormat {
+	nf := numberFormat{Label: timeUnitLabels[c.TimeUnit], Scale: c.TimeScale, Resolution: c.Resolution, Epoch: c.Epoch, Tick: c.Tick}
+	if c.Locale != "" {
+		tag := language.Make(c.Locale)
+		nf.printer = message.NewPrinter(tag)
+		if c.TranslateHeaders {
+			base, _ := tag.Base()
+			nf.headers = headerTranslations[base.String()]
+		}
+	}
+	return nf
+}
+
+// int formats a count with the locale's digit grouping.
+func (nf numberFormat) int(v int64) string {
+	if nf.printer == nil {
+		return strconv.FormatInt(v, 10)
+	}
+	return nf.printer.Sprint(number.Decimal(v))
+}
+
+// float formats v with two decimals in the locale's notation.
+func (nf numberFormat) float(v float64) string {
+	if nf.printer == nil {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return nf.printer.Sprint(number.Decimal(v, number.Scale(2)))
+}
+
+// time formats a point in time or a duration given in ticks.
+func (nf numberFormat) time(ticks int64) string {
+	if nf.scale() == 1 && nf.Resolution <= 1 {
+		return nf.int(ticks)
+	}
+	v := nf.units(float64(ticks))
+	if nf.printer == nil {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return nf.printer.Sprint(number.Decimal(v))
+}
+
+// at formats a point in time: a timestamp when there is an epoch, or else
+// the tick count as time does.
+func (nf numberFormat) at(ticks int64) string {
+	if nf.Epoch.IsZero() {
+		return nf.time(ticks)
+	}
+	tick := nf.Tick / time.Duration(max(nf.Resolution, 1))
+	return nf.Epoch.Add(time.Duration(ticks) * tick).Format(timestampLayout(tick))
+}
+
+// timestampLayout is a time-of-day layout with just enough fractional
+// digits to tell ticks of the given length apart.
+func timestampLayout(tick time.Duration) string {
+	switch {
+	case tick%time.Second == 0:
+		return "15:04:05"
+	case tick%time.Millisecond == 0:
+		return "15:04:05.000"
+	case tick%time.Microsecond == 0:
+		return "15:04:05.000000"
+	}
+	return "15:04:05.000000000"
+}
+
+// duration formats an average or other fractional tick count.
+func (nf numberFormat) duration(ticks float64) string {
+	return nf.float(nf.units(ticks))
+}
+
+// rate formats a per-tick rate, such as throughput, per unit.
+func (nf numberFormat) rate(perTick float64) string {
+	return nf.float(perTick/nf.units(1)) + "/" + nf.label()
+}
+
+// header translates a table header, falling back to the English text.
+func (nf numberFormat) header(s string) string {
+	if t, ok := nf.headers[s]; ok {
+		return t
+	}
+	return s
+}
+
+// headerTranslations holds the table headers in the languages --locale can
+// translate to, keyed by base language.
+var headerTranslations = map[string]map[string]string{
+	"de": {
+		"ID": "ID", "Priority": "Priorität", "Burst": "Rechenzeit", "Arrival": "Ankunft",
+		"Wait": "Wartezeit", "Turnaround": "Verweilzeit", "Response": "Antwortzeit", "Exit": "Ende",
+		"Average": "Durchschnitt", "Throughput": "Durchsatz", "Algorithm": "Algorithmus",
+		"Avg wait": "Ø Wartezeit", "Avg turnaround": "Ø Verweilzeit",
+		"Context switches": "Kontextwechsel", "Makespan": "Gesamtdauer",
+		"Consumer": "Verbraucher", "Time": "Zeit", "Share": "Anteil",
+		"Name": "Name", "Owner": "Besitzer", "I/O": "E/A", "Deadline": "Frist", "Missed by": "Verfehlt um",
+		"Avg response": "Ø Antwortzeit", "CPU": "CPU", "Processes": "Prozesse",
+		"Rank": "Rang", "Run queue": "Warteschlange", "Sim time": "Simulationszeit",
+		"processes": "Prozesse", "scheduler overhead": "Scheduler-Aufwand", "idle": "Leerlauf",
+		"Decisions": "Entscheidungen",
+	},
+	"es": {
+		"ID": "ID", "Priority": "Prioridad", "Burst": "Ráfaga", "Arrival": "Llegada",
+		"Wait": "Espera", "Turnaround": "Retorno", "Response": "Respuesta", "Exit": "Salida",
+		"Average": "Promedio", "Throughput": "Rendimiento", "Algorithm": "Algoritmo",
+		"Avg wait": "Espera media", "Avg turnaround": "Retorno medio",
+		"Context switches": "Cambios de contexto", "Makespan": "Duración total",
+		"Consumer": "Consumidor", "Time": "Tiempo", "Share": "Proporción",
+		"Name": "Nombre", "Owner": "Propietario", "I/O": "E/S", "Deadline": "Plazo", "Missed by": "Retraso",
+		"Avg response": "Respuesta media", "CPU": "CPU", "Processes": "Procesos",
+		"Rank": "Puesto", "Run queue": "Cola de ejecución", "Sim time": "Tiempo de simulación",
+		"processes": "procesos", "scheduler overhead": "sobrecarga del planificador", "idle": "inactiva",
+		"Decisions": "Decisiones",
+	},
+	"fr": {
+		"ID": "ID", "Priority": "Priorité", "Burst": "Rafale", "Arrival": "Arrivée",
+		"Wait": "Attente", "Turnaround": "Rotation", "Response": "Réponse", "Exit": "Fin",
+		"Average": "Moyenne", "Throughput": "Débit", "Algorithm": "Algorithme",
+		"Avg wait": "Attente moy.", "Avg turnaround": "Rotation moy.",
+		"Context switches": "Changements de contexte", "Makespan": "Durée totale",
+		"Consumer": "Consommateur", "Time": "Temps", "Share": "Part",
+		"Name": "Nom", "Owner": "Propriétaire", "I/O": "E/S", "Deadline": "Échéance", "Missed by": "Dépassement",
+		"Avg response": "Réponse moy.", "CPU": "CPU", "Processes": "Processus",
+		"Rank": "Rang", "Run queue": "File d'exécution", "Sim time": "Durée de simulation",
+		"processes": "processus", "scheduler overhead": "surcoût de l'ordonnanceur", "idle": "inactif",
+		"Decisions": "Décisions",
+	},
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_numberFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		cfg      Config
+		int      string
+		time     string
+		duration string
+		rate     string
+		header   string
+	}{
+		{name: "zero value is ticks", cfg: Config{}, int: "12345", time: "1500", duration: "2.50", rate: "0.40/t", header: "Wait"},
+		{name: "labelled", cfg: Config{TimeUnit: "ms", TimeScale: 1}, int: "12345", time: "1500", duration: "2.50", rate: "0.40/ms", header: "Wait"},
+		{name: "scaled", cfg: Config{TimeUnit: "s", TimeScale: 0.001}, int: "12345", time: "1.5", duration: "0.00", rate: "400.00/s", header: "Wait"},
+		{name: "resolution", cfg: Config{TimeScale: 1, Resolution: 1000}, int: "12345", time: "1.5", duration: "0.00", rate: "400.00/t", header: "Wait"},
+		{name: "english locale", cfg: Config{TimeScale: 1, Locale: "en-US"}, int: "12,345", time: "1,500", duration: "2.50", rate: "0.40/t", header: "Wait"},
+		{
+			name: "german locale", cfg: Config{TimeScale: 1, Locale: "de-DE", TranslateHeaders: true},
+			int: "12.345", time: "1.500", duration: "2,50", rate: "0,40/t", header: "Wartezeit",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			nf := tt.cfg.numberFormat()
+			if got := nf.int(12345); got != tt.int {
+				t.Errorf("int() = %q, want %q", got, tt.int)
+			}
+			if got := nf.time(1500); got != tt.time {
+				t.Errorf("time() = %q, want %q", got, tt.time)
+			}
+			if got := nf.duration(2.5); got != tt.duration {
+				t.Errorf("duration() = %q, want %q", got, tt.duration)
+			}
+			if got := nf.rate(0.4); got != tt.rate {
+				t.Errorf("rate() = %q, want %q", got, tt.rate)
+			}
+			if got := nf.header("Wait"); got != tt.header {
+				t.Errorf("header() = %q, want %q", got, tt.header)
+			}
+		})
+	}
+}
+
+func Test_numberFormat_at(t *testing.T) {
+	t.Parallel()
+	epoch := time.Date(2024, 5, 1, 14, 3, 5, 0, time.UTC)
+	tests := []struct {
+		name string
+		nf   numberFormat
+		want string
+	}{
+		{name: "no epoch", nf: numberFormat{}, want: "1001"},
+		{name: "seconds", nf: numberFormat{Epoch: epoch, Tick: time.Second}, want: "14:19:46"},
+		{name: "milliseconds", nf: numberFormat{Epoch: epoch, Tick: 250 * time.Millisecond}, want: "14:07:15.250"},
+		{name: "resolution", nf: numberFormat{Epoch: epoch, Tick: time.Second, Resolution: 4}, want: "14:07:15.250"},
+		{name: "microseconds", nf: numberFormat{Epoch: epoch, Tick: 5 * time.Microsecond}, want: "14:03:05.005005"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.nf.at(1001); got != tt.want {
+				t.Errorf("at() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_headerTranslations(t *testing.T) {
+	t.Parallel()
+	headers := []string{"Average", "Throughput", "Algorithm",
+		"Consumer", "Time", "Share", "processes", "scheduler overhead", "idle", "Decisions"}
+	for _, c := range scheduleColumns {
+		headers = append(headers, c.Header)
+	}
+	for _, m := range summaryMetrics {
+		headers = append(headers, m.Header)
+	}
+	for lang, translations := range headerTranslations {
+		for _, h := range headers {
+			if _, ok := translations[h]; !ok {
+				t.Errorf("headerTranslations[%q] has no translation of %q", lang, h)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// ownerShare sums up the processes of one owner.
+type ownerShare struct {
+	Owner      string
+	Processes  int
+	CPU        int64
+	Wait       int64
+	Turnaround int64
+}
+
+// ownerBreakdown groups rows by owner, sorted by owner, or returns nil
+// when no row has one. Processes without an owner are grouped under "-".
+func ownerBreakdown(rows []ScheduleRow) []ownerShare {
+	byOwner := make(map[string]*ownerShare)
+	owned := false
+	for _, row := range rows {
+		owner := row.Owner
+		if owner == "" {
+			owner = "-"
+		} else {
+			owned = true
+		}
+		s := byOwner[owner]
+		if s == nil {
+			s = &ownerShare{Owner: owner}
+			byOwner[owner] = s
+		}
+		s.Processes++
+		s.CPU += row.Burst
+		s.Wait += row.Wait
+		s.Turnaround += row.Turnaround
+	}
+	if !owned {
+		return nil
+	}
+	shares := make([]ownerShare, 0, len(byOwner))
+	for _, s := range byOwner {
+		shares = append(shares, *s)
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].Owner < shares[j].Owner })
+	return shares
+}
+
+// outputOwners breaks the schedule's metrics down by owner: the CPU time
+// each owner's processes used, as a share of busy, the time all of them
+// used, and their average wait and turnaround.
+func outputOwners(w io.Writer, shares []ownerShare, busy int64, nf numberFormat) {
+	_, _ = fmt.Fprintln(w, "Owners")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{
+		nf.header("Owner"), nf.header("Processes"), nf.header("CPU"), nf.header("Share"),
+		nf.header("Avg wait"), nf.header("Avg turnaround"),
+	})
+	table.SetColumnAlignment([]int{
+		tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT,
+		tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT,
+	})
+	for _, s := range shares {
+		share := "-"
+		if busy > 0 {
+			share = nf.float(100*float64(s.CPU)/float64(busy)) + "%"
+		}
+		n := float64(s.Processes)
+		table.Append([]string{
+			s.Owner, fmt.Sprint(s.Processes), nf.time(s.CPU), share,
+			nf.duration(float64(s.Wait) / n), nf.duration(float64(s.Turnaround) / n),
+		})
+	}
+	table.Render()
+}
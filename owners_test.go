@@ -0,0 +1,43 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ownerBreakdown(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		rows []ScheduleRow
+		want []ownerShare
+	}{
+		{
+			name: "no owners",
+			rows: []ScheduleRow{{ProcessID: 1, Burst: 5}},
+		},
+		{
+			name: "grouped and sorted by owner",
+			rows: []ScheduleRow{
+				{ProcessID: 1, Owner: "bob", Burst: 5, Wait: 0, Turnaround: 5},
+				{ProcessID: 2, Burst: 1, Wait: 2, Turnaround: 3},
+				{ProcessID: 3, Owner: "alice", Burst: 4, Wait: 5, Turnaround: 9},
+				{ProcessID: 4, Owner: "bob", Burst: 2, Wait: 6, Turnaround: 8},
+			},
+			want: []ownerShare{
+				{Owner: "-", Processes: 1, CPU: 1, Wait: 2, Turnaround: 3},
+				{Owner: "alice", Processes: 1, CPU: 4, Wait: 5, Turnaround: 9},
+				{Owner: "bob", Processes: 2, CPU: 7, Wait: 6, Turnaround: 13},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ownerBreakdown(tt.rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ownerBreakdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parsePerfSched reads the output of `perf sched timehist`, which lists
+// every time a task was switched out:
+//
+//	           time    cpu  task name                       wait time  sch delay   run time
+//	                        [tid/pid]                          (msec)     (msec)     (msec)
+//	--------------- ------  ------------------------------  ---------  ---------  ---------
+//	  79371.874569 [0011]  gcc[31949]                           0.014      0.000      1.148
+//
+// Each task becomes a process, identified by its thread ID and named by
+// its command. It arrives at its first wakeup, the start of its first run
+// less the scheduling delay, and its burst is its total run time, at least
+// one tick. The idle task, the wakeup and migration events of -w and -M,
+// and the header are skipped.
+func parsePerfSched(r io.Reader, tick time.Duration) ([]Process, error) {
+	var (
+		sc    = bufio.NewScanner(r)
+		tasks = make(map[int64]*Process)
+		runs  = make(map[int64]time.Duration)
+		line  int
+	)
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], "[") {
+			continue
+		}
+		switched, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		if text := sc.Text(); strings.Contains(text, "awakened:") || strings.Contains(text, "migrated:") {
+			continue
+		}
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("line %d: want time, cpu, task, wait time, sch delay and run time", line)
+		}
+		var msec [2]float64 // sch delay and run time
+		for i, field := range fields[len(fields)-2:] {
+			if msec[i], err = strconv.ParseFloat(field, 64); err != nil || msec[i] < 0 {
+				return nil, fmt.Errorf("line %d: %q is not a time in msec", line, field)
+			}
+		}
+		task := strings.Join(fields[2:len(fields)-3], " ")
+		if task == "<idle>" {
+			continue
+		}
+		name, tid, err := perfTask(task)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if tid == 0 {
+			continue
+		}
+
+		run := time.Duration(math.Round(msec[1] * float64(time.Millisecond)))
+		wakeup := roundTicks(time.Duration(math.Round(switched*float64(time.Second)))-run-
+			time.Duration(math.Round(msec[0]*float64(time.Millisecond))), tick)
+		p := tasks[tid]
+		if p == nil {
+			p = &Process{ProcessID: tid, Name: name, ArrivalTime: wakeup}
+			tasks[tid] = p
+		}
+		p.ArrivalTime = min(p.ArrivalTime, wakeup)
+		runs[tid] += run
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	processes := make([]Process, 0, len(tasks))
+	for tid, p := range tasks {
+		p.BurstDuration = max(roundTicks(runs[tid], tick), 1)
+		processes = append(processes, *p)
+	}
+	return importedProcesses(processes), nil
+}
+
+// perfTask splits a perf task such as "gcc[31949]" or "gcc[31949/31940]"
+// into its command and thread ID.
+func perfTask(s string) (string, int64, error) {
+	open := strings.LastIndexByte(s, '[')
+	if open < 0 || !strings.HasSuffix(s, "]") {
+		return "", 0, fmt.Errorf("task %q is not of the form name[tid]", s)
+	}
+	id, _, _ := strings.Cut(s[open+1:len(s)-1], "/")
+	tid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || tid < 0 {
+		return "", 0, fmt.Errorf("task %q is not of the form name[tid]", s)
+	}
+	return s[:open], tid, nil
+}
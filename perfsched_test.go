@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parsePerfSched(t *testing.T) {
+	t.Parallel()
+	const header = `           time    cpu  task name                       wait time  sch delay   run time
+                        [tid/pid]                          (msec)     (msec)     (msec)
+--------------- ------  ------------------------------  ---------  ---------  ---------
+`
+	tests := []struct {
+		name    string
+		input   string
+		want    []Process
+		wantErr bool
+	}{
+		{
+			name: "tasks",
+			input: header + `      10.005000 [0000]  make[100]                            0.000      0.000      5.000
+      10.006000 [0001]  <idle>                               0.000      0.000      6.000
+      10.009000 [0000]  Web Content[202/200]                 0.000      1.000      3.000
+      10.010000 [0001]  make[100]                  awakened: cc1[303]
+      10.012000 [0000]  make[100]                            4.000      0.000      2.500
+      10.020000 [0001]  cc1[303]                             0.000      0.500      0.200
+`,
+			want: []Process{
+				{ProcessID: 100, Name: "make", ArrivalTime: 0, BurstDuration: 8},
+				{ProcessID: 202, Name: "Web Content", ArrivalTime: 5, BurstDuration: 3},
+				{ProcessID: 303, Name: "cc1", ArrivalTime: 19, BurstDuration: 1},
+			},
+		},
+		{
+			name:    "malformed times",
+			input:   header + "      10.005000 [0000]  make[100]      0.000      x      5.000\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed task",
+			input:   header + "      10.005000 [0000]  make      0.000      0.000      5.000\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePerfSched(strings.NewReader(tt.input), time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePerfSched() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePerfSched() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
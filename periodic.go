@@ -0,0 +1,136 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+)
+
+// maxJobs bounds how many jobs periodic tasks may expand into, so a
+// hyperperiod too long to simulate is reported rather than attempted.
+const maxJobs = 100_000
+
+// withPeriod makes p a periodic task, released every period ticks, each
+// release delayed by up to jitter ticks. A period of zero leaves p a
+// single job.
+func withPeriod(p Process, period, jitter int64) (Process, error) {
+	switch {
+	case period < 0:
+		return p, fmt.Errorf("period must not be negative, got %d", period)
+	case jitter < 0:
+		return p, fmt.Errorf("jitter must not be negative, got %d", jitter)
+	case jitter > 0 && period == 0:
+		return p, fmt.Errorf("jitter %d needs a period", jitter)
+	case jitter > period:
+		return p, fmt.Errorf("jitter %d must not exceed the period %d", jitter, period)
+	}
+	p.Period, p.Jitter = period, jitter
+	return p, nil
+}
+
+// hyperperiod returns the least common multiple of the periods of the
+// periodic tasks among processes, or zero if there are none.
+func hyperperiod(processes []Process) (int64, error) {
+	var h int64
+	for _, p := range processes {
+		if p.Period == 0 {
+			continue
+		}
+		if h == 0 {
+			h = p.Period
+			continue
+		}
+		a, b := h, p.Period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		if h/a > math.MaxInt64/p.Period {
+			return 0, fmt.Errorf("the hyperperiod of the periodic tasks is too long to simulate")
+		}
+		h = h / a * p.Period
+	}
+	return h, nil
+}
+
+// expandPeriodic replaces each periodic task with its jobs, released every
+// period from the task's arrival until one hyperperiod has passed since
+// the latest task's first release, so the schedule covers every phasing
+// of the tasks. A job's deadline is the task's, taken as relative to each
+// release, or else the next release. Jitter delays each release by a
+// pseudo-random amount, drawn from seed and the task's PID, so it is the
+// same on every run with the same seed. The first job keeps the task's PID
+// and later ones take fresh PIDs; jobs are named after the task with their
+// number, as in "sensor#2". Once a workload has periodic tasks, its
+// processes are put in release order.
+func expandPeriodic(processes []Process, seed int64) ([]Process, error) {
+	h, err := hyperperiod(processes)
+	if err != nil || h == 0 {
+		return processes, err
+	}
+	var offset, next int64
+	for _, p := range processes {
+		if p.Period > 0 {
+			offset = max(offset, p.ArrivalTime)
+		}
+		next = max(next, p.ProcessID+1)
+	}
+	if offset > math.MaxInt64-h {
+		return nil, fmt.Errorf("the hyperperiod of the periodic tasks is too long to simulate")
+	}
+	horizon, jobs := offset+h, len(processes)
+	for _, p := range processes {
+		if p.Period > 0 {
+			jobs += int(min((horizon-p.ArrivalTime+p.Period-1)/p.Period, maxJobs))
+		}
+		if jobs > maxJobs {
+			return nil, fmt.Errorf("periodic tasks release more than %d jobs over their hyperperiod of %d", maxJobs, h)
+		}
+	}
+
+	expanded := make([]Process, 0, jobs)
+	for _, task := range processes {
+		if task.Period == 0 {
+			expanded = append(expanded, task)
+			continue
+		}
+		name := task.Name
+		if name == "" {
+			name = strconv.FormatInt(task.ProcessID, 10)
+		}
+		relative := task.Deadline
+		if relative == 0 {
+			relative = task.Period
+		}
+		rng := rand.New(rand.NewSource(jitterSeed(seed, task.ProcessID)))
+		for k, release := int64(1), task.ArrivalTime; release < horizon; k, release = k+1, release+task.Period {
+			job := task
+			job.Period, job.Jitter = 0, 0
+			job.Name = name + "#" + strconv.FormatInt(k, 10)
+			job.Bursts = slices.Clone(task.Bursts)
+			job.Deadline = release + relative
+			job.ArrivalTime = release
+			if task.Jitter > 0 {
+				job.ArrivalTime += rng.Int63n(task.Jitter + 1)
+			}
+			if k > 1 {
+				job.ProcessID = next
+				next++
+			}
+			expanded = append(expanded, job)
+		}
+	}
+	slices.SortStableFunc(expanded, func(a, b Process) int {
+		return cmp.Compare(a.ArrivalTime, b.ArrivalTime)
+	})
+	return expanded, nil
+}
+
+// jitterSeed mixes a run's seed with a task's PID, so that each task draws
+// its own jitter and another seed draws other jitter. Seed 0 leaves the
+// PID as is.
+func jitterSeed(seed, pid int64) int64 {
+	return seed*0x5DEECE66D + pid
+}
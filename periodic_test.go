@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_expandPeriodic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []Process
+		wantErr   bool
+	}{
+		{
+			name:      "no periodic tasks",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2}},
+			want:      []Process{{ProcessID: 1, BurstDuration: 2}},
+		},
+		{
+			name: "jobs over the hyperperiod",
+			processes: []Process{
+				{ProcessID: 1, Name: "sensor", BurstDuration: 1, Period: 2},
+				{ProcessID: 2, BurstDuration: 2, Period: 3, Deadline: 2},
+				{ProcessID: 3, BurstDuration: 4, ArrivalTime: 1},
+			},
+			want: []Process{
+				{ProcessID: 1, Name: "sensor#1", BurstDuration: 1, Deadline: 2},
+				{ProcessID: 2, Name: "2#1", BurstDuration: 2, Deadline: 2},
+				{ProcessID: 3, BurstDuration: 4, ArrivalTime: 1},
+				{ProcessID: 4, Name: "sensor#2", BurstDuration: 1, ArrivalTime: 2, Deadline: 4},
+				{ProcessID: 6, Name: "2#2", BurstDuration: 2, ArrivalTime: 3, Deadline: 5},
+				{ProcessID: 5, Name: "sensor#3", BurstDuration: 1, ArrivalTime: 4, Deadline: 6},
+			},
+		},
+		{
+			name: "offset tasks cover a hyperperiod past the last first release",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 2},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 3, Period: 2},
+			},
+			want: []Process{
+				{ProcessID: 1, Name: "1#1", BurstDuration: 1, Deadline: 2},
+				{ProcessID: 3, Name: "1#2", BurstDuration: 1, ArrivalTime: 2, Deadline: 4},
+				{ProcessID: 2, Name: "2#1", BurstDuration: 1, ArrivalTime: 3, Deadline: 5},
+				{ProcessID: 4, Name: "1#3", BurstDuration: 1, ArrivalTime: 4, Deadline: 6},
+			},
+		},
+		{
+			name: "too many jobs",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Period: 1},
+				{ProcessID: 2, BurstDuration: 1, Period: 1_000_003},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := expandPeriodic(tt.processes, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandPeriodic() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPeriodic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandPeriodic_jitter(t *testing.T) {
+	t.Parallel()
+	task := []Process{{ProcessID: 1, BurstDuration: 1, Period: 10, Jitter: 3}, {ProcessID: 2, BurstDuration: 1, Period: 50}}
+	first, _ := expandPeriodic(task, 7)
+	again, _ := expandPeriodic(task, 7)
+	if !reflect.DeepEqual(first, again) {
+		t.Errorf("expandPeriodic() = %v, then %v, want the same jobs each run", first, again)
+	}
+	if other, _ := expandPeriodic(task, 8); reflect.DeepEqual(first, other) {
+		t.Errorf("expandPeriodic() drew the same jitter under seeds 7 and 8: %v", first)
+	}
+	for _, job := range first {
+		if nominal := job.Deadline - 10; job.ProcessID != 2 && (job.ArrivalTime < nominal || job.ArrivalTime > nominal+3) {
+			t.Errorf("job %d released at %d, want within the jitter of %d", job.ProcessID, job.ArrivalTime, nominal)
+		}
+	}
+}
+
+func Test_parseWorkload_periods(t *testing.T) {
+	t.Parallel()
+	input := "pid,burst,arrival,period,jitter\n1,1,0,2,0\n2,1,0,0,1\n3,1,0,2,3\n"
+	got, problems := parseWorkload(strings.NewReader(input), loadOptions{})
+	want := []Process{
+		{ProcessID: 1, Name: "1#1", BurstDuration: 1, Deadline: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorkload() = %v, want %v", got, want)
+	}
+	wantProblems := []workloadProblem{
+		{Line: 3, Msg: "jitter 1 needs a period"},
+		{Line: 4, Msg: "jitter 3 must not exceed the period 2"},
+	}
+	if !reflect.DeepEqual(problems, wantProblems) {
+		t.Errorf("parseWorkload() problems = %v, want %v", problems, wantProblems)
+	}
+}
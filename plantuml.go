@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process states in a PlantUML timing diagram. A process is hidden before
+// it arrives and after it completes.
+const (
+	umlHidden  = "{-}"
+	umlReady   = "Ready"
+	umlRunning = "Running"
+	umlBlocked = "Blocked"
+)
+
+// outputPlantUML writes a run's results as PlantUML timing diagrams, one
+// per algorithm, with a robust line per process stepping between ready,
+// running and blocked. Times are in raw ticks. With a --from/--to window,
+// a diagram opens on every process's state at its start and leaves out
+// the changes after its end.
+func outputPlantUML(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	opts := cfg.outputOptions()
+	for i, row := range summary {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		r := row.Result
+		var b strings.Builder
+		fmt.Fprintf(&b, "@startuml\ntitle %s\n", row.Scheduler.Title)
+		for _, p := range r.Schedule {
+			label := strconv.FormatInt(p.ProcessID, 10)
+			if p.Name != "" {
+				label += " " + p.Name
+			}
+			fmt.Fprintf(&b, "robust %q as P%d\n", label, p.ProcessID)
+			fmt.Fprintf(&b, "P%d has %s,%s,%s\n", p.ProcessID, umlBlocked, umlReady, umlRunning)
+		}
+		for _, step := range windowTimeline(umlTimeline(r), opts.from, opts.to) {
+			fmt.Fprintf(&b, "@%d\n", step.at)
+			for _, c := range step.changes {
+				fmt.Fprintf(&b, "P%d is %s\n", c.pid, c.state)
+			}
+		}
+		b.WriteString("@enduml\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type umlChange struct {
+	pid   int64
+	state string
+}
+
+type umlStep struct {
+	at      int64
+	changes []umlChange
+}
+
+// umlTimeline works out when each process in r changes state, in time
+// order, with the processes changing at the same time in schedule order.
+// Where several events fall on one process at once, the later of
+// arrival or wakeup, blocking, dispatch and completion wins.
+func umlTimeline(r Result) []umlStep {
+	states := make(map[int64]map[int64]string)
+	set := func(at, pid int64, state string) {
+		if states[at] == nil {
+			states[at] = make(map[int64]string)
+		}
+		states[at][pid] = state
+	}
+	for _, p := range r.Schedule {
+		set(0, p.ProcessID, umlHidden)
+		set(p.Arrival, p.ProcessID, umlReady)
+	}
+	for _, s := range r.Gantt {
+		if !s.Idle {
+			set(s.Stop, s.PID, umlReady)
+		}
+	}
+	for _, s := range r.Blocked {
+		set(s.Stop, s.PID, umlReady)
+	}
+	for _, s := range r.Blocked {
+		set(s.Start, s.PID, umlBlocked)
+	}
+	for _, s := range r.Gantt {
+		if !s.Idle {
+			set(s.Start, s.PID, umlRunning)
+		}
+	}
+	for _, p := range r.Schedule {
+		set(p.Exit, p.ProcessID, umlHidden)
+	}
+
+	times := make([]int64, 0, len(states))
+	for at := range states {
+		times = append(times, at)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	last := make(map[int64]string, len(r.Schedule))
+	var steps []umlStep
+	for _, at := range times {
+		step := umlStep{at: at}
+		for _, p := range r.Schedule {
+			state, ok := states[at][p.ProcessID]
+			if prev, seen := last[p.ProcessID]; !ok || (seen && prev == state) {
+				continue
+			}
+			last[p.ProcessID] = state
+			step.changes = append(step.changes, umlChange{pid: p.ProcessID, state: state})
+		}
+		if len(step.changes) > 0 {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// windowTimeline keeps the steps of a timeline inside [from, to), opening
+// with the state each process is in at from. A zero to means no upper
+// bound.
+func windowTimeline(steps []umlStep, from, to int64) []umlStep {
+	if from == 0 && to == 0 {
+		return steps
+	}
+	opening := umlStep{at: from}
+	at := make(map[int64]int) // each process's place in opening.changes
+	var kept []umlStep
+	for _, step := range steps {
+		if to != 0 && step.at >= to {
+			break
+		}
+		if step.at > from {
+			kept = append(kept, step)
+			continue
+		}
+		for _, c := range step.changes {
+			if i, ok := at[c.pid]; ok {
+				opening.changes[i] = c
+				continue
+			}
+			at[c.pid] = len(opening.changes)
+			opening.changes = append(opening.changes, c)
+		}
+	}
+	if len(opening.changes) == 0 {
+		return kept
+	}
+	return append([]umlStep{opening}, kept...)
+}
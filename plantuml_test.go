@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputPlantUML(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, Name: "editor", BurstDuration: 3, Bursts: []Burst{{Duration: 2}, {Duration: 3, IO: true}, {Duration: 1}}},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1},
+	}
+	summary := []summaryRow{{
+		Scheduler: Scheduler{Title: "First-come, first-serve"},
+		Result:    simulate(processes, &fcfsPolicy{}, simOptions{}),
+	}}
+	var w bytes.Buffer
+	if err := outputPlantUML(&w, Config{}, nil, summary); err != nil {
+		t.Fatal(err)
+	}
+	want := "@startuml\ntitle First-come, first-serve\n" +
+		"robust \"1 editor\" as P1\nP1 has Blocked,Ready,Running\n" +
+		"robust \"2\" as P2\nP2 has Blocked,Ready,Running\n" +
+		"@0\nP1 is Running\nP2 is {-}\n" +
+		"@1\nP2 is Ready\n" +
+		"@2\nP1 is Blocked\nP2 is Running\n" +
+		"@4\nP2 is {-}\n" +
+		"@5\nP1 is Running\n" +
+		"@6\nP1 is {-}\n" +
+		"@enduml\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputPlantUML() =\n%s\nwant\n%s", got, want)
+	}
+
+	w.Reset()
+	if err := outputPlantUML(&w, Config{From: 2, To: 5}, nil, summary); err != nil {
+		t.Fatal(err)
+	}
+	want = "@startuml\ntitle First-come, first-serve\n" +
+		"robust \"1 editor\" as P1\nP1 has Blocked,Ready,Running\n" +
+		"robust \"2\" as P2\nP2 has Blocked,Ready,Running\n" +
+		"@2\nP1 is Blocked\nP2 is Running\n" +
+		"@4\nP2 is {-}\n" +
+		"@enduml\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputPlantUML() in the window 2..5 =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiling starts the profiles requested in cfg. The returned stop
+// function ends the CPU profile and writes the heap profile, so calls in
+// between are what gets profiled.
+func startProfiling(cfg Config) (stop func() error, err error) {
+	var cpu *os.File
+	if cfg.CPUProfile != "" {
+		cpu, err = os.Create(cfg.CPUProfile)
+		if err != nil {
+			return nil, fmt.Errorf("%v: error creating CPU profile", err)
+		}
+		if err := pprof.StartCPUProfile(cpu); err != nil {
+			cpu.Close()
+			return nil, fmt.Errorf("%v: error starting CPU profile", err)
+		}
+	}
+
+	return func() error {
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			if err := cpu.Close(); err != nil {
+				return fmt.Errorf("%v: error writing CPU profile", err)
+			}
+		}
+		if cfg.HeapProfile != "" {
+			f, err := os.Create(cfg.HeapProfile)
+			if err != nil {
+				return fmt.Errorf("%v: error creating heap profile", err)
+			}
+			defer f.Close()
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				return fmt.Errorf("%v: error writing heap profile", err)
+			}
+			return f.Close()
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func Test_startProfiling(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := defaultConfig()
+	cfg.CPUProfile = path.Join(dir, "cpu.out")
+	cfg.HeapProfile = path.Join(dir, "heap.out")
+
+	stop, err := startProfiling(cfg)
+	if err != nil {
+		t.Fatalf("startProfiling() error = %v", err)
+	}
+	simulate([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}, newSJFPolicy(defaultRunQueue), simOptions{})
+	if err := stop(); err != nil {
+		t.Fatalf("stop() error = %v", err)
+	}
+
+	for _, p := range []string{cfg.CPUProfile, cfg.HeapProfile} {
+		if info, err := os.Stat(p); err != nil || info.Size() == 0 {
+			t.Errorf("profile %s not written: %v", p, err)
+		}
+	}
+}
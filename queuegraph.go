@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// queueSnapshot is the ready queue as one scheduling decision saw it.
+// Running is the PID that held the CPU, or -1 if it was free.
+type queueSnapshot struct {
+	Time    int64
+	Running int64
+	Ready   []int64
+	Chosen  int64
+	Reason  string
+}
+
+// queueGraph collects every algorithm's ready queue at each decision, for
+// --queue-graph to draw as a Graphviz chain of snapshots.
+type queueGraph struct {
+	titles    []string
+	snapshots [][]queueSnapshot
+}
+
+// algorithm starts the snapshots of the next algorithm to run and returns
+// the observer that records them.
+func (g *queueGraph) algorithm(title string) func(d decision) {
+	g.titles = append(g.titles, title)
+	g.snapshots = append(g.snapshots, nil)
+	i := len(g.snapshots) - 1
+	return func(d decision) {
+		s := queueSnapshot{Time: d.Time, Running: -1, Ready: make([]int64, len(d.Ready)), Chosen: d.Chosen.ProcessID, Reason: d.Reason}
+		if d.Previous != nil {
+			s.Running = d.Previous.ProcessID
+		}
+		for j, t := range d.Ready {
+			s.Ready[j] = t.ProcessID
+		}
+		g.snapshots[i] = append(g.snapshots[i], s)
+	}
+}
+
+// writeFile writes the graph to path in DOT.
+func (g *queueGraph) writeFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating queue graph", err)
+	}
+	defer f.Close()
+	if err := g.write(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// write draws each algorithm as a cluster of record nodes, one per
+// decision, top to bottom in time order: the time, what was running, the
+// ready queue in the order the policy would pick from it, and the pick
+// with its reason.
+func (g *queueGraph) write(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph queues {\n\tnode [shape=record, fontname=\"monospace\"];\n")
+	for i, title := range g.titles {
+		fmt.Fprintf(&b, "\tsubgraph cluster_%d {\n\t\tlabel=%q;\n", i, title)
+		for j, s := range g.snapshots[i] {
+			running := "-"
+			if s.Running >= 0 {
+				running = strconv.FormatInt(s.Running, 10)
+			}
+			ready := []string{"ready"}
+			for _, pid := range s.Ready {
+				ready = append(ready, strconv.FormatInt(pid, 10))
+			}
+			if len(s.Ready) == 0 {
+				ready = append(ready, "-")
+			}
+			label := fmt.Sprintf("{t=%d|running %s|{%s}|chose %d: %s}",
+				s.Time, running, strings.Join(ready, "|"), s.Chosen, dotRecordEscape(s.Reason))
+			fmt.Fprintf(&b, "\t\tn%d_%d [label=\"%s\"];\n", i, j, label)
+			if j > 0 {
+				fmt.Fprintf(&b, "\t\tn%d_%d -> n%d_%d;\n", i, j-1, i, j)
+			}
+		}
+		b.WriteString("\t}\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotRecordEscape escapes the characters that structure a record label.
+func dotRecordEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`).Replace(s)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_queueGraph(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, BurstDuration: 1, Priority: 1, ArrivalTime: 1},
+	}
+	var g queueGraph
+	simulate(processes, newPriorityPolicy(defaultRunQueue), simOptions{observer: g.algorithm("Priority")})
+	var w bytes.Buffer
+	if err := g.write(&w); err != nil {
+		t.Fatal(err)
+	}
+	want := "digraph queues {\n\tnode [shape=record, fontname=\"monospace\"];\n" +
+		"\tsubgraph cluster_0 {\n\t\tlabel=\"Priority\";\n" +
+		"\t\tn0_0 [label=\"{t=0|running -|{ready|1}|chose 1: smallest priority (2)}\"];\n" +
+		"\t\tn0_1 [label=\"{t=1|running 1|{ready|2}|chose 2: preempts 1, priority 1 \\< 2}\"];\n" +
+		"\t\tn0_0 -> n0_1;\n" +
+		"\t\tn0_2 [label=\"{t=2|running -|{ready|1}|chose 1: smallest priority (2)}\"];\n" +
+		"\t\tn0_1 -> n0_2;\n" +
+		"\t}\n}\n"
+	if got := w.String(); got != want {
+		t.Errorf("queueGraph.write() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// runRepeated simulates every algorithm on n generated workloads, seeded
+// seed, seed+1, ..., and prints the mean and sample standard deviation of
+// each summary metric across them.
+func runRepeated(w io.Writer, spec generatorSpec, seed int64, n int) error {
+	if n < 1 {
+		return fmt.Errorf("%w: repeat must be at least 1, got %d", ErrInvalidArgs, n)
+	}
+	cfg := defaultConfig()
+	samples := make([][][]float64, len(schedulers)) // [scheduler][metric][run]
+	for i := range samples {
+		samples[i] = make([][]float64, len(summaryMetrics))
+	}
+	for run := 0; run < n; run++ {
+		processes := generateProcesses(rand.New(rand.NewSource(seed+int64(run))), spec)
+		for i, s := range schedulers {
+			r := s.Schedule(processes, cfg)
+			for j, m := range summaryMetrics {
+				samples[i][j] = append(samples[i][j], m.Value(r))
+			}
+		}
+	}
+
+	header := []string{"Algorithm"}
+	for _, m := range summaryMetrics {
+		header = append(header, m.Header)
+	}
+	_, _ = fmt.Fprintf(w, "%d runs, seeds %d..%d, mean ± standard deviation\n", n, seed, seed+int64(n)-1)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	for i, s := range schedulers {
+		row := []string{s.Name}
+		for j := range summaryMetrics {
+			mean, sd := meanStddev(samples[i][j])
+			row = append(row, fmt.Sprintf("%.2f ± %.2f", mean, sd))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	return nil
+}
+
+// meanStddev returns the mean and sample standard deviation of xs, with
+// a deviation of zero for a single sample.
+func meanStddev(xs []float64) (mean, sd float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	for _, x := range xs {
+		sd += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(xs)-1))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func Test_meanStddev(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		xs       []float64
+		mean, sd float64
+	}{
+		{name: "single sample", xs: []float64{3}, mean: 3, sd: 0},
+		{name: "sample deviation", xs: []float64{2, 4, 4, 4, 5, 5, 7, 9}, mean: 5, sd: math.Sqrt(32.0 / 7)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mean, sd := meanStddev(tt.xs)
+			if mean != tt.mean || math.Abs(sd-tt.sd) > 1e-9 {
+				t.Errorf("meanStddev() = %v, %v, want %v, %v", mean, sd, tt.mean, tt.sd)
+			}
+		})
+	}
+}
+
+func Test_generate_repeat(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	if err := generate(&w, "-seed", "7", "-repeat", "5", "-n", "8"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(w.String(), "5 runs, seeds 7..11,") {
+		t.Errorf("generate() = %q, want a 5-run summary", w.String())
+	}
+	for _, s := range schedulers {
+		if !strings.Contains(w.String(), "| "+s.Name+" ") && !strings.Contains(w.String(), " "+s.Name+" |") {
+			t.Errorf("generate() is missing a row for %s:\n%s", s.Name, w.String())
+		}
+	}
+
+	if err := generate(&w, "-seed", "7", "-repeat", "-1"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("generate() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
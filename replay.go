@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// recording is one algorithm's schedule read back from a file rather than
+// simulated.
+type recording struct {
+	name   string
+	result Result
+}
+
+// recordingFields lists the fields each record of a recording may carry,
+// and which of them are required.
+var recordingFields = map[string]map[string]bool{
+	"slice":   {"pid": true, "start": true, "stop": true},
+	"process": {"pid": true, "arrival": true, "burst": true, "exit": true, "priority": false, "wait": false, "turnaround": false, "response": false},
+}
+
+// parseRecording reads schedules in the --canonical format, as written by
+// this tool or by an external scheduler. Each algorithm line starts a new
+// schedule; records before the first belong to one named "recorded".
+// Process records need only pid, arrival, burst and exit, and slices are
+// optional: everything else is derived, and the averages are recomputed.
+// Given waits and turnarounds must agree with the rest, and given slices
+// must form a valid schedule.
+func parseRecording(r io.Reader) ([]recording, error) {
+	var (
+		sc   = bufio.NewScanner(r)
+		runs []recording
+		cur  *recordingBuilder
+		line int
+	)
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		result, err := cur.result()
+		if err != nil {
+			return fmt.Errorf("%w: algorithm %s: %v", ErrInvalidWorkload, cur.name, err)
+		}
+		runs = append(runs, recording{name: cur.name, result: result})
+		return nil
+	}
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") || text == canonicalVersion {
+			continue
+		}
+		keyword, rest, _ := strings.Cut(text, " ")
+		switch keyword {
+		case "algorithm":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			cur = &recordingBuilder{name: strings.TrimSpace(rest)}
+			continue
+		case "average":
+			// Averages are recomputed from the process records.
+			continue
+		}
+		want, ok := recordingFields[keyword]
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: unknown record %q, want algorithm, slice, process or average", ErrInvalidWorkload, line, keyword)
+		}
+		fields, err := recordFields(rest, want)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s: %v", ErrInvalidWorkload, line, keyword, err)
+		}
+		if cur == nil {
+			cur = &recordingBuilder{name: "recorded"}
+		}
+		if keyword == "slice" {
+			cur.gantt = append(cur.gantt, TimeSlice{PID: fields["pid"], Start: fields["start"], Stop: fields["stop"]})
+			continue
+		}
+		if err := cur.addProcess(fields); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidWorkload, line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkload, err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("%w: the recording holds no schedules", ErrInvalidWorkload)
+	}
+	return runs, nil
+}
+
+// recordFields parses a record's key=value fields, each of which must be
+// a known, non-negative integer given once, and checks that the required
+// ones are there.
+func recordFields(s string, want map[string]bool) (map[string]int64, error) {
+	fields := make(map[string]int64, len(want))
+	for _, field := range strings.Fields(s) {
+		key, value, ok := strings.Cut(field, "=")
+		if _, known := want[key]; !ok || !known {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("%s given twice", key)
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+		}
+		fields[key] = v
+	}
+	for key, required := range want {
+		if _, ok := fields[key]; required && !ok {
+			return nil, fmt.Errorf("missing %s", key)
+		}
+	}
+	return fields, nil
+}
+
+// recordingBuilder collects one algorithm's records.
+type recordingBuilder struct {
+	name  string
+	gantt []TimeSlice
+	tasks []*task
+	given []map[string]int64
+}
+
+func (b *recordingBuilder) addProcess(fields map[string]int64) error {
+	for _, t := range b.tasks {
+		if t.ProcessID == fields["pid"] {
+			return fmt.Errorf("process %d recorded twice", t.ProcessID)
+		}
+	}
+	if fields["exit"] < fields["arrival"]+fields["burst"] {
+		return fmt.Errorf("process %d exits at %d, before it could have run its burst of %d from %d",
+			fields["pid"], fields["exit"], fields["burst"], fields["arrival"])
+	}
+	b.tasks = append(b.tasks, &task{
+		Process: Process{
+			ProcessID:     fields["pid"],
+			ArrivalTime:   fields["arrival"],
+			BurstDuration: fields["burst"],
+			Priority:      fields["priority"],
+		},
+		completion: fields["exit"],
+	})
+	b.given = append(b.given, fields)
+	return nil
+}
+
+// result assembles the recorded schedule into a Result. A process's
+// response comes from its record, or else from its first slice.
+func (b *recordingBuilder) result() (Result, error) {
+	if len(b.tasks) == 0 {
+		return Result{}, fmt.Errorf("no process records")
+	}
+	firstRun := make(map[int64]int64)
+	for i := len(b.gantt) - 1; i >= 0; i-- {
+		firstRun[b.gantt[i].PID] = b.gantt[i].Start
+	}
+	for i, t := range b.tasks {
+		t.firstRun = t.ArrivalTime
+		if response, ok := b.given[i]["response"]; ok {
+			t.firstRun += response
+		} else if start, ok := firstRun[t.ProcessID]; ok {
+			t.firstRun = start
+		}
+	}
+	sort.SliceStable(b.tasks, func(i, j int) bool { return b.tasks[i].ArrivalTime < b.tasks[j].ArrivalTime })
+
+	r := Result{Gantt: b.gantt}
+	if r.Gantt == nil {
+		r.Gantt = make([]TimeSlice, 0)
+	}
+	for i := 1; i < len(r.Gantt); i++ {
+		if r.Gantt[i].PID != r.Gantt[i-1].PID {
+			r.ContextSwitches++
+		}
+	}
+	finishResult(&r, b.tasks)
+	r.Gantt = fillIdle(r.Gantt)
+
+	for _, given := range b.given {
+		for _, row := range r.Schedule {
+			if row.ProcessID != given["pid"] {
+				continue
+			}
+			if wait, ok := given["wait"]; ok && wait != row.Wait {
+				return Result{}, fmt.Errorf("process %d waits %d, but its arrival, burst and exit give %d", row.ProcessID, wait, row.Wait)
+			}
+			if turnaround, ok := given["turnaround"]; ok && turnaround != row.Turnaround {
+				return Result{}, fmt.Errorf("process %d turns around in %d, but its arrival and exit give %d", row.ProcessID, turnaround, row.Turnaround)
+			}
+		}
+	}
+	if len(b.gantt) > 0 {
+		processes := make([]Process, len(b.tasks))
+		for i, t := range b.tasks {
+			processes[i] = t.Process
+		}
+		if err := checkInvariants(processes, r); err != nil {
+			return Result{}, err
+		}
+	}
+	return r, nil
+}
+
+// replay renders recorded schedules, such as --canonical output or the
+// results of an external scheduler, as if they had been simulated here.
+// It accepts the same display flags as a regular run.
+func replay(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"replay"}, args...)...)
+	if err != nil {
+		return err
+	}
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+	runs, err := parseRecording(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.Name(), err)
+	}
+
+	if cfg.Canonical {
+		_, _ = fmt.Fprintln(w, canonicalVersion)
+	}
+	summary := make([]summaryRow, 0, len(runs))
+	for _, run := range runs {
+		s, ok := lookupScheduler(run.name)
+		if !ok {
+			s = Scheduler{Name: run.name, Title: run.name}
+		}
+		if cfg.Canonical {
+			outputCanonical(w, run.name, run.result)
+		} else {
+			outputResult(w, s.Title, run.result, cfg.outputOptions())
+		}
+		summary = append(summary, summaryRow{Scheduler: s, Result: run.result})
+	}
+	if cfg.Compare && !cfg.Canonical {
+		outputComparison(w, summary, cfg.outputOptions())
+	}
+	return nil
+}
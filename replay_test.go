@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func Test_parseRecording_roundTrip(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var recorded bytes.Buffer
+	cfg := defaultConfig()
+	for _, s := range schedulers {
+		outputCanonical(&recorded, s.Name, simulate(processes, s.NewPolicy(cfg), cfg.simOptions()))
+	}
+
+	runs, err := parseRecording(strings.NewReader(canonicalVersion + "\n" + recorded.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replayed bytes.Buffer
+	for _, run := range runs {
+		outputCanonical(&replayed, run.name, run.result)
+	}
+	if replayed.String() != recorded.String() {
+		t.Errorf("replayed recording =\n%s\nwant\n%s", replayed.String(), recorded.String())
+	}
+}
+
+func Test_parseRecording(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		input        string
+		wantName     string
+		wantResponse int64
+		wantErr      error
+	}{
+		{
+			name:         "completion times only",
+			input:        "process pid=1 arrival=2 burst=3 exit=9\n",
+			wantName:     "recorded",
+			wantResponse: 0,
+		},
+		{
+			name:         "response from the first slice",
+			input:        "algorithm ext\nslice pid=1 start=4 stop=7\nprocess pid=1 arrival=2 burst=3 exit=7\n",
+			wantName:     "ext",
+			wantResponse: 2,
+		},
+		{
+			name:    "missing exit",
+			input:   "process pid=1 arrival=2 burst=3\n",
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name:    "inconsistent wait",
+			input:   "process pid=1 arrival=0 burst=3 exit=5 wait=1\n",
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name:    "slices disagree with the burst",
+			input:   "slice pid=1 start=0 stop=2\nprocess pid=1 arrival=0 burst=3 exit=3\n",
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name:    "unknown record",
+			input:   "event pid=1\n",
+			wantErr: ErrInvalidWorkload,
+		},
+		{
+			name:    "empty",
+			input:   "# nothing recorded\n",
+			wantErr: ErrInvalidWorkload,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			runs, err := parseRecording(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseRecording() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if runs[0].name != tt.wantName {
+				t.Errorf("parseRecording() name = %q, want %q", runs[0].name, tt.wantName)
+			}
+			if got := runs[0].result.Schedule[0].Response; got != tt.wantResponse {
+				t.Errorf("parseRecording() response = %d, want %d", got, tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// resourceUsage is what running one algorithm cost the tool itself. CPU
+// and PeakRSS are zero where the platform cannot report them; PeakRSS is
+// the process's high-water mark so far, so it only rises on the run that
+// pushed it up.
+type resourceUsage struct {
+	Name      string
+	Wall      time.Duration
+	CPU       time.Duration
+	Allocated uint64
+	PeakRSS   uint64
+}
+
+// resourceMeter measures the resources used between its start and stop.
+type resourceMeter struct {
+	wall      time.Time
+	cpu       time.Duration
+	allocated uint64
+}
+
+func startResourceMeter() resourceMeter {
+	m := resourceMeter{allocated: heapAllocated()}
+	m.cpu, _ = processCPUTime()
+	m.wall = time.Now()
+	return m
+}
+
+func (m resourceMeter) stop(name string) resourceUsage {
+	u := resourceUsage{Name: name, Wall: time.Since(m.wall)}
+	u.Allocated = heapAllocated() - m.allocated
+	if cpu, ok := processCPUTime(); ok {
+		u.CPU = cpu - m.cpu
+	}
+	u.PeakRSS, _ = peakRSS()
+	return u
+}
+
+// heapAllocated counts every byte the program has allocated on the heap,
+// freed or not. Unlike runtime/metrics, whose count only moves a span at
+// a time, ReadMemStats flushes the per-P caches, so small runs register.
+func heapAllocated() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.TotalAlloc
+}
+
+// outputResourceUsage prints a table of what each algorithm's run cost.
+func outputResourceUsage(w io.Writer, usage []resourceUsage) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Wall time", "CPU time", "Allocated", "Peak RSS"})
+	for _, u := range usage {
+		cpu := "-"
+		if u.CPU > 0 {
+			cpu = u.CPU.String()
+		}
+		rss := "-"
+		if u.PeakRSS > 0 {
+			rss = formatBytes(u.PeakRSS)
+		}
+		table.Append([]string{u.Name, u.Wall.String(), cpu, formatBytes(u.Allocated), rss})
+	}
+	table.Render()
+}
+
+// formatBytes renders n bytes in the largest binary unit that keeps the
+// number at least 1.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
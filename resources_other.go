@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "time"
+
+func processCPUTime() (time.Duration, bool) { return 0, false }
+
+func peakRSS() (uint64, bool) { return 0, false }
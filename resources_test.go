@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_formatBytes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{n: 0, want: "0 B"},
+		{n: 1023, want: "1023 B"},
+		{n: 1024, want: "1.0 KiB"},
+		{n: 1536, want: "1.5 KiB"},
+		{n: 5 << 20, want: "5.0 MiB"},
+		{n: 3 << 30, want: "3.0 GiB"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			if got := formatBytes(tt.n); got != tt.want {
+				t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resourceMeter(t *testing.T) {
+	meter := startResourceMeter()
+	sink := make([][]byte, 0, 64)
+	for i := 0; i < 64; i++ {
+		sink = append(sink, make([]byte, 1<<10))
+	}
+	u := meter.stop("fcfs")
+	if len(sink) != 64 || u.Allocated < 64<<10 {
+		t.Errorf("Allocated = %d, want at least %d", u.Allocated, 64<<10)
+	}
+
+	var buf bytes.Buffer
+	outputResourceUsage(&buf, []resourceUsage{u})
+	if !strings.Contains(buf.String(), "fcfs") {
+		t.Errorf("outputResourceUsage() = %q, want a row for fcfs", buf.String())
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// runQueue holds the ready tasks of a key-ordered policy, ordered by key
+// and then arrival. Every implementation yields the same order, so the
+// choice only changes how fast the simulation runs.
+type runQueue interface {
+	push(t *task)
+	// min returns the first task without removing it.
+	min() *task
+	// popMin removes and returns the first task.
+	popMin() *task
+	len() int
+	// tasks returns the queued tasks in no particular order.
+	tasks() []*task
+}
+
+// defaultRunQueue is used when no --run-queue is configured.
+const defaultRunQueue = "heap"
+
+// runQueues maps each --run-queue name onto its constructor, given the
+// task key to order by.
+var runQueues = map[string]func(key func(t *task) int64) runQueue{
+	"list":   func(key func(t *task) int64) runQueue { return &listQueue{key: key} },
+	"heap":   func(key func(t *task) int64) runQueue { return &heapQueue{h: taskHeap{key: key}} },
+	"rbtree": func(key func(t *task) int64) runQueue { return &treeQueue{key: key} },
+	"bucket": func(key func(t *task) int64) runQueue { return &bucketQueue{key: key} },
+}
+
+// runQueueNames lists the implementations in the order they are reported.
+var runQueueNames = []string{"list", "heap", "rbtree", "bucket"}
+
+// taskBefore reports whether a runs before b: smaller key, then earlier
+// arrival.
+func taskBefore(key func(t *task) int64, a, b *task) bool {
+	if ka, kb := key(a), key(b); ka != kb {
+		return ka < kb
+	}
+	return a.index < b.index
+}
+
+// sortTasks orders tasks the way a run queue would pop them.
+func sortTasks(key func(t *task) int64, tasks []*task) {
+	sort.Slice(tasks, func(i, j int) bool { return taskBefore(key, tasks[i], tasks[j]) })
+}
+
+// listQueue is an unordered slice scanned in full for the minimum: O(1)
+// push, O(n) pop.
+type listQueue struct {
+	items []*task
+	key   func(t *task) int64
+}
+
+func (q *listQueue) push(t *task) { q.items = append(q.items, t) }
+
+func (q *listQueue) minIndex() int {
+	m := 0
+	for i := 1; i < len(q.items); i++ {
+		if taskBefore(q.key, q.items[i], q.items[m]) {
+			m = i
+		}
+	}
+	return m
+}
+
+func (q *listQueue) min() *task { return q.items[q.minIndex()] }
+
+func (q *listQueue) popMin() *task {
+	m := q.minIndex()
+	t := q.items[m]
+	q.items = append(q.items[:m], q.items[m+1:]...)
+	return t
+}
+
+func (q *listQueue) len() int { return len(q.items) }
+
+func (q *listQueue) tasks() []*task { return q.items }
+
+// heapQueue is a binary min-heap: O(log n) push and pop.
+type heapQueue struct {
+	h taskHeap
+}
+
+func (q *heapQueue) push(t *task) { heap.Push(&q.h, t) }
+
+func (q *heapQueue) min() *task { return q.h.tasks[0] }
+
+func (q *heapQueue) popMin() *task { return heap.Pop(&q.h).(*task) }
+
+func (q *heapQueue) len() int { return q.h.Len() }
+
+func (q *heapQueue) tasks() []*task { return q.h.tasks }
+
+// taskHeap is a min-heap of tasks ordered by key, then arrival order.
+type taskHeap struct {
+	tasks []*task
+	key   func(t *task) int64
+}
+
+func (h taskHeap) Len() int { return len(h.tasks) }
+
+func (h taskHeap) Less(i, j int) bool { return taskBefore(h.key, h.tasks[i], h.tasks[j]) }
+
+func (h taskHeap) Swap(i, j int) {
+	h.tasks[i], h.tasks[j] = h.tasks[j], h.tasks[i]
+}
+
+func (h *taskHeap) Push(x any) {
+	h.tasks = append(h.tasks, x.(*task))
+}
+
+func (h *taskHeap) Pop() any {
+	old := h.tasks
+	n := len(old)
+	x := old[n-1]
+	h.tasks = old[0 : n-1]
+	return x
+}
+
+// treeQueue is a left-leaning red-black tree: O(log n) push and pop,
+// with the minimum at the leftmost node.
+type treeQueue struct {
+	root *treeNode
+	n    int
+	key  func(t *task) int64
+}
+
+type treeNode struct {
+	t           *task
+	left, right *treeNode
+	red         bool
+}
+
+func isRed(n *treeNode) bool { return n != nil && n.red }
+
+func rotateLeft(h *treeNode) *treeNode {
+	x := h.right
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func rotateRight(h *treeNode) *treeNode {
+	x := h.left
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func flipColors(h *treeNode) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+func fixUp(h *treeNode) *treeNode {
+	if isRed(h.right) && !isRed(h.left) {
+		h = rotateLeft(h)
+	}
+	if isRed(h.left) && isRed(h.left.left) {
+		h = rotateRight(h)
+	}
+	if isRed(h.left) && isRed(h.right) {
+		flipColors(h)
+	}
+	return h
+}
+
+func (q *treeQueue) push(t *task) {
+	q.root = q.insert(q.root, t)
+	q.root.red = false
+	q.n++
+}
+
+func (q *treeQueue) insert(h *treeNode, t *task) *treeNode {
+	if h == nil {
+		return &treeNode{t: t, red: true}
+	}
+	if taskBefore(q.key, t, h.t) {
+		h.left = q.insert(h.left, t)
+	} else {
+		h.right = q.insert(h.right, t)
+	}
+	return fixUp(h)
+}
+
+func (q *treeQueue) min() *task {
+	h := q.root
+	for h.left != nil {
+		h = h.left
+	}
+	return h.t
+}
+
+func (q *treeQueue) popMin() *task {
+	t := q.min()
+	if !isRed(q.root.left) && !isRed(q.root.right) {
+		q.root.red = true
+	}
+	q.root = deleteMin(q.root)
+	if q.root != nil {
+		q.root.red = false
+	}
+	q.n--
+	return t
+}
+
+func deleteMin(h *treeNode) *treeNode {
+	if h.left == nil {
+		return nil
+	}
+	if !isRed(h.left) && !isRed(h.left.left) {
+		flipColors(h)
+		if isRed(h.right.left) {
+			h.right = rotateRight(h.right)
+			h = rotateLeft(h)
+			flipColors(h)
+		}
+	}
+	h.left = deleteMin(h.left)
+	return fixUp(h)
+}
+
+func (q *treeQueue) len() int { return q.n }
+
+func (q *treeQueue) tasks() []*task {
+	tasks := make([]*task, 0, q.n)
+	var walk func(h *treeNode)
+	walk = func(h *treeNode) {
+		if h == nil {
+			return
+		}
+		walk(h.left)
+		tasks = append(tasks, h.t)
+		walk(h.right)
+	}
+	walk(q.root)
+	return tasks
+}
+
+// bucketQueue keeps one arrival-ordered bucket per key value in use and a
+// min-heap of those keys. Push and pop cost O(log k) for k distinct keys
+// queued, so it beats the task heap when many tasks share few keys, such
+// as priorities; memory grows with the tasks queued, however wide the
+// range of keys.
+type bucketQueue struct {
+	buckets map[int64][]*task
+	keys    keyHeap // of the non-empty buckets
+	n       int
+	key     func(t *task) int64
+}
+
+func (q *bucketQueue) push(t *task) {
+	k := q.key(t)
+	if q.buckets == nil {
+		q.buckets = make(map[int64][]*task)
+	}
+	b := q.buckets[k]
+	if len(b) == 0 {
+		heap.Push(&q.keys, k)
+	}
+
+	// Tasks mostly arrive in index order; a preempted task is the one
+	// that may have to be slotted in earlier.
+	at := len(b)
+	for at > 0 && b[at-1].index > t.index {
+		at--
+	}
+	b = append(b, nil)
+	copy(b[at+1:], b[at:])
+	b[at] = t
+	q.buckets[k] = b
+	q.n++
+}
+
+func (q *bucketQueue) min() *task { return q.buckets[q.keys[0]][0] }
+
+func (q *bucketQueue) popMin() *task {
+	k := q.keys[0]
+	b := q.buckets[k]
+	t := b[0]
+	if len(b) == 1 {
+		delete(q.buckets, k)
+		heap.Pop(&q.keys)
+	} else {
+		q.buckets[k] = b[1:]
+	}
+	q.n--
+	return t
+}
+
+func (q *bucketQueue) len() int { return q.n }
+
+func (q *bucketQueue) tasks() []*task {
+	tasks := make([]*task, 0, q.n)
+	for _, k := range q.keys {
+		tasks = append(tasks, q.buckets[k]...)
+	}
+	return tasks
+}
+
+// keyHeap is a min-heap of bucket keys.
+type keyHeap []int64
+
+func (h keyHeap) Len() int { return len(h) }
+
+func (h keyHeap) Less(i, j int) bool { return h[i] < h[j] }
+
+func (h keyHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *keyHeap) Push(x any) { *h = append(*h, x.(int64)) }
+
+func (h *keyHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
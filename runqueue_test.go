@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_runQueues(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+	tasks := make([]*task, 200)
+	for i := range tasks {
+		tasks[i] = &task{index: i, Process: Process{ProcessID: int64(i), Priority: rng.Int63n(20) - 5}}
+	}
+	key := func(t *task) int64 { return t.Priority }
+
+	// Interleave pushes and pops, sometimes requeueing the popped task
+	// the way a preempted task is, and record the pop order.
+	pushes := make([]int, 0, len(tasks))
+	requeue := make([]bool, 0, len(tasks))
+	for range tasks {
+		pushes = append(pushes, rng.Intn(4))
+		requeue = append(requeue, rng.Intn(3) == 0)
+	}
+	popOrder := func(name string) []int64 {
+		q := runQueues[name](key)
+		var order []int64
+		pushed := 0
+		for step := 0; pushed < len(tasks) || q.len() > 0; step++ {
+			for n := pushes[step%len(pushes)]; n > 0 && pushed < len(tasks); n-- {
+				q.push(tasks[pushed])
+				pushed++
+			}
+			if q.len() == 0 {
+				continue
+			}
+			if got := len(q.tasks()); got != q.len() {
+				t.Fatalf("%s: tasks() has %d tasks, len() = %d", name, got, q.len())
+			}
+			min := q.min()
+			next := q.popMin()
+			if next != min {
+				t.Fatalf("%s: popMin() = %d, min() = %d", name, next.ProcessID, min.ProcessID)
+			}
+			if requeue[step%len(requeue)] {
+				q.push(next)
+				next = q.popMin()
+			}
+			order = append(order, next.ProcessID)
+		}
+		return order
+	}
+
+	want := popOrder("list")
+	for _, name := range runQueueNames {
+		if got := popOrder(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s pop order = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func Test_runQueues_simulate(t *testing.T) {
+	t.Parallel()
+	processes := generateProcesses(rand.New(rand.NewSource(3)), generatorSpec{Count: 300, MaxArrival: 500, MaxBurst: 20, MaxPriority: 5})
+	for _, newPolicy := range []func(queue string) *keyPolicy{newSJFPolicy, newPriorityPolicy} {
+		want := simulate(processes, newPolicy(defaultRunQueue), simOptions{})
+		for _, name := range runQueueNames {
+			if got := simulate(processes, newPolicy(name), simOptions{}); !reflect.DeepEqual(got, want) {
+				t.Errorf("simulate() with the %s run queue differs from %s", name, defaultRunQueue)
+			}
+		}
+	}
+}
+
+func Test_runQueues_wideKeyRange(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 5000000000},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 1},
+		{ProcessID: 4, BurstDuration: 1, ArrivalTime: 2, Priority: -5000000000},
+	}
+	want := simulate(processes, newPriorityPolicy("list"), simOptions{})
+	for _, name := range runQueueNames {
+		if got := simulate(processes, newPriorityPolicy(name), simOptions{}); !reflect.DeepEqual(got, want) {
+			t.Errorf("simulate() with the %s run queue = %v, want %v", name, got.Gantt, want.Gantt)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// scenarioSettings are the keys of a scenario file besides its workload:
+// the settings of a --config file, and what the run needs and should
+// produce.
+type scenarioSettings struct {
+	Config `yaml:",inline"`
+	// CPUs is the CPU count the scenario was written for, which must be
+	// the number the simulator runs.
+	CPUs int `yaml:"cpus" toml:"cpus"`
+	// Generate, with a nonzero Seed, stands in for the processes with a
+	// reproducible random workload.
+	Generate *generatorSpec `yaml:"generate" toml:"generate"`
+	// Expect holds the results each algorithm should produce, by name.
+	Expect map[string]expectedResult `yaml:"expect" toml:"expect"`
+}
+
+// expectedResult is what a scenario expects of one algorithm's run. Only
+// the metrics given are checked.
+type expectedResult struct {
+	AvgWait         *float64 `yaml:"avg_wait" toml:"avg_wait"`
+	AvgTurnaround   *float64 `yaml:"avg_turnaround" toml:"avg_turnaround"`
+	AvgResponse     *float64 `yaml:"avg_response" toml:"avg_response"`
+	Throughput      *float64 `yaml:"throughput" toml:"throughput"`
+	ContextSwitches *int     `yaml:"context_switches" toml:"context_switches"`
+	Makespan        *int64   `yaml:"makespan" toml:"makespan"`
+}
+
+// expectTolerance is how far an average may be from the one expected,
+// enough to write it as the two decimals the report prints.
+const expectTolerance = 0.005
+
+// misses lists every metric of r that differs from the one expected.
+func (e expectedResult) misses(r Result) []string {
+	var msgs []string
+	averages := []struct {
+		name string
+		want *float64
+		got  float64
+	}{
+		{"avg wait", e.AvgWait, r.AveWait},
+		{"avg turnaround", e.AvgTurnaround, r.AveTurnaround},
+		{"avg response", e.AvgResponse, r.AveResponse},
+		{"throughput", e.Throughput, r.AveThroughput},
+	}
+	for _, a := range averages {
+		if a.want != nil && math.Abs(a.got-*a.want) > expectTolerance {
+			msgs = append(msgs, fmt.Sprintf("%s is %g, want %g", a.name, a.got, *a.want))
+		}
+	}
+	if e.ContextSwitches != nil && r.ContextSwitches != *e.ContextSwitches {
+		msgs = append(msgs, fmt.Sprintf("context switches is %d, want %d", r.ContextSwitches, *e.ContextSwitches))
+	}
+	if e.Makespan != nil && r.Makespan != *e.Makespan {
+		msgs = append(msgs, fmt.Sprintf("makespan is %d, want %d", r.Makespan, *e.Makespan))
+	}
+	return msgs
+}
+
+// isScenario reports whether path names a scenario file, one that can
+// carry settings alongside its processes.
+func isScenario(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml", ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// loadScenario decodes the settings of a scenario file over cfg, so keys
+// left out of the file keep their current values, and returns the rest of
+// its settings. The processes are left to the workload loader.
+func loadScenario(path string, cfg *Config) (scenarioSettings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return scenarioSettings{}, fmt.Errorf("%w: %v: error opening scenario file", ErrInvalidArgs, err)
+	}
+	defer f.Close()
+
+	settings := scenarioSettings{Config: *cfg}
+	var processes int
+	if isTOML(path) {
+		doc := tomlWorkload{scenarioSettings: settings}
+		md, err := toml.NewDecoder(f).Decode(&doc)
+		if err != nil {
+			return scenarioSettings{}, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+		if keys := md.Undecoded(); len(keys) > 0 {
+			return scenarioSettings{}, fmt.Errorf("%w: %s: unknown key %q", ErrInvalidConfig, path, keys[0].String())
+		}
+		settings, processes = doc.scenarioSettings, len(doc.Processes)
+	} else {
+		doc := yamlWorkload{scenarioSettings: settings}
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+			return scenarioSettings{}, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+		}
+		settings, processes = doc.scenarioSettings, len(doc.Processes)
+	}
+
+	switch {
+	case settings.CPUs != 0 && settings.CPUs != simulatedCPUs:
+		return scenarioSettings{}, fmt.Errorf("%w: %s: written for %d CPUs, but the simulator runs %d", ErrInvalidConfig, path, settings.CPUs, simulatedCPUs)
+	case settings.Generate != nil && settings.Seed == 0:
+		return scenarioSettings{}, fmt.Errorf("%w: %s: generate needs a nonzero seed", ErrInvalidConfig, path)
+	case settings.Generate != nil && processes > 0:
+		return scenarioSettings{}, fmt.Errorf("%w: %s: a generated workload cannot have processes as well", ErrInvalidConfig, path)
+	}
+	if settings.Generate != nil {
+		if err := settings.Generate.validate(); err != nil {
+			return scenarioSettings{}, fmt.Errorf("%s: generate: %w", path, err)
+		}
+	}
+	*cfg = settings.Config
+	return settings, nil
+}
+
+// runScenario runs a scenario file, a whole experiment in one place: its
+// settings, which flags still override, its processes or seeded generated
+// workload, and the results it expects, which are checked once the run's
+// output is written.
+func runScenario(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"run"}, args...)...)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 || !isScenario(args[1]) {
+		return fmt.Errorf("%w: run takes a .yaml, .json or .toml scenario file", ErrInvalidArgs)
+	}
+	sc, err := loadScenario(args[1], new(Config))
+	if err != nil {
+		return err
+	}
+	if len(sc.Expect) > 0 && (len(cfg.Grid) > 0 || cfg.Sweep != "") {
+		return fmt.Errorf("%w: %s: expected results cannot be checked in a sweep or grid", ErrInvalidConfig, args[1])
+	}
+	for name := range sc.Expect {
+		if !slices.Contains(cfg.Algorithms, name) {
+			return fmt.Errorf("%w: %s: expects results of %q, which is not run", ErrInvalidConfig, args[1], name)
+		}
+	}
+
+	var processes []Process
+	if sc.Generate != nil {
+		processes = generateProcesses(rand.New(rand.NewSource(cfg.Seed)), *sc.Generate)
+	} else {
+		f, closeFile, err := openProcessingFile(args...)
+		if err != nil {
+			return err
+		}
+		defer closeFile()
+		if processes, err = loadWorkload(f.Name(), f, cfg.loadOptions()); err != nil {
+			return err
+		}
+	}
+
+	if err := run(w, cfg, processes); err != nil {
+		return err
+	}
+	var misses int
+	for _, name := range cfg.Algorithms {
+		want, ok := sc.Expect[name]
+		if !ok {
+			continue
+		}
+		s, _ := lookupScheduler(name)
+		for _, msg := range want.misses(s.Schedule(processes, cfg)) {
+			_, _ = fmt.Fprintf(w, "%s: %s: %s\n", args[1], name, msg)
+			misses++
+		}
+	}
+	if misses > 0 {
+		return fmt.Errorf("%w: %s: %d expected result(s) not met", ErrAssertion, args[1], misses)
+	}
+	return nil
+}
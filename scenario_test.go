@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func Test_runScenario(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeScenario := func(name, content string) string {
+		p := path.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	const processes = `processes:
+  - {pid: 1, burst: 5, arrival: 0}
+  - {pid: 2, burst: 9, arrival: 3}
+`
+	met := writeScenario("met.yaml", "algorithms: [fcfs]\ncpus: 1\n"+processes+
+		"expect:\n  fcfs: {avg_wait: 1, avg_turnaround: 8, context_switches: 1, makespan: 14}\n")
+	missed := writeScenario("missed.yaml", "algorithms: [fcfs, rr]\nquantum: 3\n"+processes+
+		"expect:\n  rr: {avg_wait: 1.33}\n")
+	generated := writeScenario("generated.json",
+		`{"algorithms": ["sjf"], "seed": 7, "generate": {"count": 4, "max_arrival": 5, "max_burst": 6, "max_priority": 3}}`)
+	toml := writeScenario("scenario.toml", "algorithms = [\"rr\"]\n\n[expect.rr]\nmakespan = 2\n\n[[processes]]\npid = 1\nburst = 2\narrival = 0\n")
+	cpus := writeScenario("cpus.yaml", "cpus: 4\n"+processes)
+	unseeded := writeScenario("unseeded.yaml", "generate: {count: 4, max_arrival: 5, max_burst: 6, max_priority: 3}\n")
+	notRun := writeScenario("notrun.yaml", "algorithms: [fcfs]\n"+processes+"expect:\n  rr: {makespan: 14}\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{name: "expectations met", args: []string{met}, want: "First-come, first-serve"},
+		{name: "expectation missed", args: []string{missed}, want: "rr: avg wait is 2.5, want 1.33", wantErr: ErrAssertion},
+		{name: "flags override the scenario", args: []string{"--quantum", "1", missed}, want: "Round-robin", wantErr: ErrAssertion},
+		{name: "generated workload", args: []string{generated}, want: "Shortest-job-first"},
+		{name: "toml", args: []string{toml}, want: "Round-robin"},
+		{name: "cpu count", args: []string{cpus}, wantErr: ErrInvalidConfig},
+		{name: "seed without generate", args: []string{unseeded}, wantErr: ErrInvalidConfig},
+		{name: "expected algorithm not run", args: []string{notRun}, wantErr: ErrInvalidConfig},
+		{name: "not a scenario", args: []string{"example_processes.csv"}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := runScenario(&w, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runScenario() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(w.String(), tt.want) {
+				t.Errorf("runScenario() = %v, want it to contain %q", w.String(), tt.want)
+			}
+		})
+	}
+}
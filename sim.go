@@ -0,0 +1,544 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// task is the simulator's bookkeeping for one process.
+type task struct {
+	Process
+	index      int   // position in arrival order, breaks ties between equal keys
+	remaining  int64 // of the current CPU burst
+	burst      int   // index into Bursts of the current CPU burst
+	wakeAt     int64 // when the I/O burst the task is blocked on ends
+	waitingOn  int   // dependencies yet to complete
+	dependents []*task
+	started    bool
+	firstRun   int64
+	completion int64
+}
+
+// hasIO reports whether an I/O burst follows the task's current CPU burst.
+func (t *task) hasIO() bool { return t.burst+1 < len(t.Bursts) }
+
+// startIO moves the task past its finished CPU burst and the I/O burst
+// after it, returning how long the I/O takes.
+func (t *task) startIO() int64 {
+	io := t.Bursts[t.burst+1].Duration
+	t.burst += 2
+	t.remaining = t.Bursts[t.burst].Duration
+	return io
+}
+
+// pendingTasks hands tasks to a policy as they arrive, return from I/O
+// or, if they arrived earlier, see their last dependency complete.
+// Arrivals due at the same time are pushed before the others. A task that
+// arrives with dependencies still running is held back until they finish.
+type pendingTasks struct {
+	tasks   []*task // every task, in arrival order
+	next    int     // the first task yet to arrive
+	blocked []*task // waiting on I/O, earliest wake first
+}
+
+// admit pushes every task due by now.
+func (p *pendingTasks) admit(now int64, pol policy) {
+	for p.next < len(p.tasks) && p.tasks[p.next].ArrivalTime <= now {
+		if p.tasks[p.next].waitingOn == 0 {
+			pol.push(p.tasks[p.next])
+		}
+		p.next++
+	}
+	for len(p.blocked) > 0 && p.blocked[0].wakeAt <= now {
+		pol.push(p.blocked[0])
+		p.blocked = p.blocked[1:]
+	}
+}
+
+// block holds t back until wakeAt, after earlier and equal wakes.
+func (p *pendingTasks) block(t *task, wakeAt int64) {
+	t.wakeAt = wakeAt
+	i := sort.Search(len(p.blocked), func(i int) bool { return p.blocked[i].wakeAt > wakeAt })
+	p.blocked = append(p.blocked, nil)
+	copy(p.blocked[i+1:], p.blocked[i:])
+	p.blocked[i] = t
+}
+
+// complete releases the tasks waiting on t, which completed at now. Those
+// that have already arrived are due at once.
+func (p *pendingTasks) complete(t *task, now int64) {
+	for _, d := range t.dependents {
+		d.waitingOn--
+		if d.waitingOn == 0 && d.index < p.next {
+			p.block(d, now)
+		}
+	}
+}
+
+// nextAt returns when the next task is due, if any is still pending.
+func (p *pendingTasks) nextAt() (int64, bool) {
+	var (
+		at int64
+		ok bool
+	)
+	if p.next < len(p.tasks) {
+		at, ok = p.tasks[p.next].ArrivalTime, true
+	}
+	if len(p.blocked) > 0 && (!ok || p.blocked[0].wakeAt < at) {
+		at, ok = p.blocked[0].wakeAt, true
+	}
+	return at, ok
+}
+
+// due reports whether a task is pending and due by now.
+func (p *pendingTasks) due(now int64) bool {
+	at, ok := p.nextAt()
+	return ok && at <= now
+}
+
+// policy owns the ready queue and decides which task holds the CPU.
+type policy interface {
+	// push adds a task that has become ready.
+	push(t *task)
+	// len reports how many tasks are waiting in the ready queue.
+	len() int
+	// ready returns the waiting tasks in the order they would be picked.
+	ready() []*task
+	// pick is called at every scheduling decision with the task currently
+	// on the CPU (nil when the CPU is free). It returns the task to run
+	// next, queueing running again if it is displaced, and why.
+	pick(running *task) (*task, reason)
+	// preemptive reports whether an arrival should trigger a decision
+	// while a task is running.
+	preemptive() bool
+	// quantum bounds how long a task runs before the scheduler is invoked
+	// again; zero lets it run until it completes or is preempted.
+	quantum() int64
+}
+
+// decision describes one invocation of the scheduler, for observers.
+type decision struct {
+	Time int64
+	// Ready holds the waiting tasks, in pick order, when the scheduler ran.
+	Ready []*task
+	// Previous is the task that was on the CPU, or nil if it was free.
+	Previous *task
+	Chosen   *task
+	Reason   string
+	// Why is Reason unformatted, for observers that word it themselves.
+	Why reason
+}
+
+// reason records why a policy picked a task. It is only formatted when an
+// observer asks for it, so deciding does not build strings.
+type reason struct {
+	kind    reasonKind
+	pid     int64 // the displaced task
+	keyName string
+	key     int64 // the chosen task's key
+	other   int64 // the displaced task's key
+}
+
+type reasonKind int
+
+const (
+	reasonKeepsRunning reasonKind = iota
+	reasonEarliestArrival
+	reasonNextInQueue
+	reasonQuantumNoOther
+	reasonQuantumRotates
+	reasonSmallestKey
+	reasonStillSmallest
+	reasonPreempts
+)
+
+func (r reason) String() string {
+	switch r.kind {
+	case reasonKeepsRunning:
+		return "non-preemptive, keeps running"
+	case reasonEarliestArrival:
+		return "earliest arrival in the ready queue"
+	case reasonNextInQueue:
+		return "next in the ready queue"
+	case reasonQuantumNoOther:
+		return "quantum expired, no other process ready"
+	case reasonQuantumRotates:
+		return fmt.Sprintf("quantum expired, %d moves to the back of the queue", r.pid)
+	case reasonSmallestKey:
+		return fmt.Sprintf("smallest %s (%d)", r.keyName, r.key)
+	case reasonStillSmallest:
+		return fmt.Sprintf("running process still has the smallest %s (%d)", r.keyName, r.key)
+	case reasonPreempts:
+		return fmt.Sprintf("preempts %d, %s %d < %d", r.pid, r.keyName, r.key, r.other)
+	}
+	return fmt.Sprintf("reason(%d)", r.kind)
+}
+
+// Hooks are callbacks invoked as the simulation runs, through which the
+// event formats, the event log and custom metrics follow a run without
+// touching the simulator. Any of them may be nil.
+type Hooks struct {
+	// OnDispatch is called when pid is given the CPU at time now.
+	OnDispatch func(now, pid int64)
+	// OnPreempt is called when pid loses the CPU to next before it has
+	// completed, whether to a preemption or an expired quantum.
+	OnPreempt func(now, pid, next int64)
+	// OnComplete is called when pid finishes at time now.
+	OnComplete func(now, pid int64)
+	// OnBlock is called when pid leaves the CPU at time now to wait on
+	// I/O until wake.
+	OnBlock func(now, pid, wake int64)
+	// OnIdle is called for each span [from, to) the CPU spends with no
+	// process ready.
+	OnIdle func(from, to int64)
+}
+
+// simOptions tune the simulation independently of the policy.
+type simOptions struct {
+	// overhead is the CPU time the scheduler itself consumes per decision.
+	overhead int64
+	// observer, when set, is called after every scheduling decision.
+	observer func(d decision)
+	hooks    Hooks
+	// metrics are collected alongside the built-in results.
+	metrics []Metric
+	// tiesByPID queues processes that arrive at the same time in PID order
+	// rather than in the order they appear in the workload.
+	tiesByPID bool
+}
+
+// arrivalTies maps the --arrival-ties values onto how the run header
+// describes them.
+var arrivalTies = map[string]string{
+	"file": "file order",
+	"pid":  "lowest PID first",
+}
+
+func (c Config) simOptions() simOptions {
+	return simOptions{overhead: c.SchedOverhead, tiesByPID: c.ArrivalTies == "pid", metrics: metricPlugins}
+}
+
+// simulate runs processes to completion under pol. Time advances from one
+// event (arrival, completion, quantum expiry, I/O) to the next rather than
+// tick by tick; the scheduler is invoked whenever the CPU frees up, a
+// quantum expires or, for preemptive policies, a process arrives or
+// returns from I/O. A process with bursts leaves the CPU at the end of
+// each CPU burst for its I/O burst, which overlaps with other processes'
+// I/O and computation. A process with dependencies only becomes ready
+// once they have all completed; until then it waits, as far as its
+// metrics are concerned, like any other. Processes that arrive together
+// are queued in workload order, or in PID order with tiesByPID, and every
+// policy breaks ties between equal keys the same way.
+func simulate(processes []Process, pol policy, opts simOptions) Result {
+	tasks := newTasks(processes, opts.tiesByPID)
+	collectors := make([]MetricCollector, len(opts.metrics))
+	if len(collectors) > 0 {
+		hooks := []Hooks{opts.hooks}
+		for i, m := range opts.metrics {
+			collectors[i] = m.New()
+			hooks = append(hooks, collectors[i].Hooks())
+		}
+		opts.hooks = chainHooks(hooks...)
+	}
+
+	var (
+		r          = Result{Gantt: make([]TimeSlice, 0, len(tasks))}
+		pending    = pendingTasks{tasks: tasks}
+		now        int64
+		done       int
+		running    *task
+		lastRan    *task
+		sliceStart int64
+		resched    bool
+	)
+
+	for done < len(tasks) {
+		pending.admit(now, pol)
+		if running == nil && pol.len() == 0 {
+			at, _ := pending.nextAt()
+			if opts.hooks.OnIdle != nil {
+				opts.hooks.OnIdle(now, at)
+			}
+			if at > now {
+				r.Gantt = append(r.Gantt, TimeSlice{Start: now, Stop: at, Idle: true})
+			}
+			now = at
+			continue
+		}
+
+		if running == nil || resched {
+			resched = false
+			r.Decisions++
+			if opts.overhead > 0 {
+				now += opts.overhead
+				r.Overhead += opts.overhead
+				pending.admit(now, pol)
+			}
+			var (
+				previous = running
+				ready    []*task
+				why      reason
+			)
+			if opts.observer != nil {
+				ready = pol.ready()
+			}
+			running, why = pol.pick(running)
+			if opts.observer != nil {
+				opts.observer(decision{Time: now, Ready: ready, Previous: previous, Chosen: running, Reason: why.String(), Why: why})
+			}
+			if lastRan != nil && lastRan != running {
+				r.ContextSwitches++
+			}
+			if previous != running {
+				if previous != nil && opts.hooks.OnPreempt != nil {
+					opts.hooks.OnPreempt(now, previous.ProcessID, running.ProcessID)
+				}
+				if opts.hooks.OnDispatch != nil {
+					opts.hooks.OnDispatch(now, running.ProcessID)
+				}
+			}
+			if !running.started {
+				running.started = true
+				running.firstRun = now
+			}
+			lastRan = running
+			sliceStart = now
+			if n := len(r.Gantt); n == 0 || r.Gantt[n-1].Idle || r.Gantt[n-1].PID != running.ProcessID || r.Gantt[n-1].Stop != now {
+				r.Gantt = append(r.Gantt, TimeSlice{PID: running.ProcessID, Start: now, Stop: now})
+			}
+		}
+
+		// Run until the next event that could change the decision.
+		until := now + running.remaining
+		if at, ok := pending.nextAt(); ok && at < until {
+			until = at
+		}
+		if q := pol.quantum(); q > 0 && sliceStart+q < until {
+			until = sliceStart + q
+		}
+		running.remaining -= until - now
+		now = until
+		r.Gantt[len(r.Gantt)-1].Stop = now
+
+		switch {
+		case running.remaining == 0 && running.hasIO():
+			wake := now + running.startIO()
+			pending.block(running, wake)
+			r.Blocked = append(r.Blocked, TimeSlice{PID: running.ProcessID, Start: now, Stop: wake})
+			if opts.hooks.OnBlock != nil {
+				opts.hooks.OnBlock(now, running.ProcessID, wake)
+			}
+			running = nil
+		case running.remaining == 0:
+			running.completion = now
+			pending.complete(running, now)
+			if opts.hooks.OnComplete != nil {
+				opts.hooks.OnComplete(now, running.ProcessID)
+			}
+			running = nil
+			done++
+		case pol.quantum() > 0 && now-sliceStart >= pol.quantum():
+			resched = true
+		case pol.preemptive() && pending.due(now):
+			resched = true
+		}
+	}
+
+	finishResult(&r, tasks)
+	if len(collectors) > 0 {
+		r.Metrics = make(map[string]float64, len(collectors))
+		for i, c := range collectors {
+			r.Metrics[opts.metrics[i].Name] = c.Value(r)
+		}
+	}
+
+	return r
+}
+
+// newTasks wraps processes for simulation, sorted by arrival with ties in
+// workload order, or PID order with tiesByPID, and indexed in that order.
+func newTasks(processes []Process, tiesByPID bool) []*task {
+	backing := make([]task, len(processes))
+	tasks := make([]*task, len(processes))
+	for i := range processes {
+		backing[i] = task{Process: processes[i], remaining: processes[i].BurstDuration}
+		if bursts := processes[i].Bursts; len(bursts) > 0 {
+			backing[i].remaining = bursts[0].Duration
+		}
+		tasks[i] = &backing[i]
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if a, b := tasks[i].ArrivalTime, tasks[j].ArrivalTime; a != b {
+			return a < b
+		}
+		return tiesByPID && tasks[i].ProcessID < tasks[j].ProcessID
+	})
+	byPID := make(map[int64]*task, len(tasks))
+	for i := range tasks {
+		tasks[i].index = i
+		byPID[tasks[i].ProcessID] = tasks[i]
+	}
+	// Dependencies outside the workload, such as on processes a minimized
+	// workload dropped, are taken as met.
+	for _, t := range tasks {
+		for _, pid := range t.DependsOn {
+			if d, ok := byPID[pid]; ok && d != t {
+				t.waitingOn++
+				d.dependents = append(d.dependents, t)
+			}
+		}
+	}
+	return tasks
+}
+
+// finishResult fills in r's schedule table and averages once every task
+// has completed.
+func finishResult(r *Result, tasks []*task) {
+	var (
+		totalWait       int64
+		totalTurnaround int64
+		totalResponse   int64
+		lastCompletion  int64
+		firstArrival    int64
+	)
+	if len(tasks) > 0 {
+		firstArrival = tasks[0].ArrivalTime
+	}
+	r.Schedule = make([]ScheduleRow, len(tasks))
+	for i, t := range tasks {
+		row := ScheduleRow{
+			ProcessID:  t.ProcessID,
+			Name:       t.Name,
+			Owner:      t.Owner,
+			Priority:   t.Priority,
+			Burst:      t.BurstDuration,
+			Arrival:    t.ArrivalTime,
+			Turnaround: t.completion - t.ArrivalTime,
+			Response:   t.firstRun - t.ArrivalTime,
+			Exit:       t.completion,
+			Deadline:   t.Deadline,
+		}
+		if t.Deadline > 0 && t.completion > t.Deadline {
+			row.MissedBy = t.completion - t.Deadline
+		}
+		for _, b := range t.Bursts {
+			if b.IO {
+				row.IO += b.Duration
+			}
+		}
+		row.Wait = row.Turnaround - t.BurstDuration - row.IO
+		totalWait += row.Wait
+		totalTurnaround += row.Turnaround
+		totalResponse += row.Response
+		r.Busy += t.BurstDuration
+		if lastCompletion < t.completion {
+			lastCompletion = t.completion
+		}
+		r.Schedule[i] = row
+	}
+
+	count := float64(len(tasks))
+	r.AveWait = float64(totalWait) / count
+	r.AveTurnaround = float64(totalTurnaround) / count
+	r.AveResponse = float64(totalResponse) / count
+	if lastCompletion > 0 {
+		// A run whose every process completes at time 0 has no throughput.
+		r.AveThroughput = count / float64(lastCompletion)
+	}
+	r.Idle = lastCompletion - r.Busy - r.Overhead
+	r.Makespan = lastCompletion - firstArrival
+}
+
+// fcfsPolicy runs tasks in arrival order, each until it completes.
+type fcfsPolicy struct {
+	queue []*task
+}
+
+func (p *fcfsPolicy) push(t *task) { p.queue = append(p.queue, t) }
+
+func (p *fcfsPolicy) len() int { return len(p.queue) }
+
+func (p *fcfsPolicy) ready() []*task { return append([]*task(nil), p.queue...) }
+
+func (p *fcfsPolicy) pick(running *task) (*task, reason) {
+	if running != nil {
+		return running, reason{kind: reasonKeepsRunning}
+	}
+	t := p.queue[0]
+	p.queue = p.queue[1:]
+	return t, reason{kind: reasonEarliestArrival}
+}
+
+func (p *fcfsPolicy) preemptive() bool { return false }
+
+func (p *fcfsPolicy) quantum() int64 { return 0 }
+
+// rrPolicy rotates through the ready queue, moving the running task to the
+// back whenever its quantum expires and another task is waiting.
+type rrPolicy struct {
+	fcfsPolicy
+	q int64
+}
+
+func (p *rrPolicy) pick(running *task) (*task, reason) {
+	if running == nil {
+		t, _ := p.fcfsPolicy.pick(nil)
+		return t, reason{kind: reasonNextInQueue}
+	}
+	if len(p.queue) == 0 {
+		return running, reason{kind: reasonQuantumNoOther}
+	}
+	p.push(running)
+	t, _ := p.fcfsPolicy.pick(nil)
+	return t, reason{kind: reasonQuantumRotates, pid: running.ProcessID}
+}
+
+func (p *rrPolicy) quantum() int64 { return p.q }
+
+// keyPolicy runs the ready task with the smallest key, preempting the
+// running task only for a strictly smaller one.
+type keyPolicy struct {
+	q       runQueue
+	key     func(t *task) int64
+	keyName string
+}
+
+// newKeyPolicy orders tasks by key in the named run queue implementation.
+func newKeyPolicy(queue, keyName string, key func(t *task) int64) *keyPolicy {
+	return &keyPolicy{q: runQueues[queue](key), key: key, keyName: keyName}
+}
+
+func newSJFPolicy(queue string) *keyPolicy {
+	return newKeyPolicy(queue, "remaining time", func(t *task) int64 { return t.remaining })
+}
+
+func newPriorityPolicy(queue string) *keyPolicy {
+	return newKeyPolicy(queue, "priority", func(t *task) int64 { return t.Priority })
+}
+
+func (p *keyPolicy) push(t *task) { p.q.push(t) }
+
+func (p *keyPolicy) len() int { return p.q.len() }
+
+func (p *keyPolicy) ready() []*task {
+	sorted := append([]*task(nil), p.q.tasks()...)
+	sortTasks(p.key, sorted)
+	return sorted
+}
+
+func (p *keyPolicy) pick(running *task) (*task, reason) {
+	if running == nil {
+		t := p.q.popMin()
+		return t, reason{kind: reasonSmallestKey, keyName: p.keyName, key: p.key(t)}
+	}
+	if p.q.len() == 0 || p.key(p.q.min()) >= p.key(running) {
+		return running, reason{kind: reasonStillSmallest, keyName: p.keyName, key: p.key(running)}
+	}
+	p.q.push(running)
+	t := p.q.popMin()
+	return t, reason{kind: reasonPreempts, pid: running.ProcessID, keyName: p.keyName, key: p.key(t), other: p.key(running)}
+}
+
+func (p *keyPolicy) preemptive() bool { return true }
+
+func (p *keyPolicy) quantum() int64 { return 0 }
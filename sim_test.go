@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_simulate(t *testing.T) {
+	t.Parallel()
+	example := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	type args struct {
+		processes []Process
+		pol       policy
+		opts      simOptions
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantGantt     []TimeSlice
+		wantWait      float64
+		wantOverhead  int64
+		wantIdle      int64
+		wantDecisions int
+	}{
+		{
+			name: "sjf preempts for shorter remaining time",
+			args: args{processes: example, pol: newSJFPolicy(defaultRunQueue)},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 5, Stop: 6},
+				{PID: 3, Start: 6, Stop: 12},
+				{PID: 2, Start: 12, Stop: 20},
+			},
+			wantWait:      8.0 / 3,
+			wantDecisions: 5,
+		},
+		{
+			name: "priority preempts for lower priority value",
+			args: args{processes: example, pol: newPriorityPolicy(defaultRunQueue)},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3},
+				{PID: 2, Start: 3, Stop: 12},
+				{PID: 1, Start: 12, Stop: 14},
+				{PID: 3, Start: 14, Stop: 20},
+			},
+			wantWait:      17.0 / 3,
+			wantDecisions: 5,
+		},
+		{
+			name: "simultaneous arrivals run in file order",
+			args: args{
+				processes: []Process{
+					{ProcessID: 7, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				},
+				pol: &fcfsPolicy{},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 7, Start: 0, Stop: 2},
+				{PID: 3, Start: 2, Stop: 4},
+			},
+			wantWait:      1,
+			wantDecisions: 2,
+		},
+		{
+			name: "simultaneous arrivals run in PID order",
+			args: args{
+				processes: []Process{
+					{ProcessID: 7, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2},
+				},
+				pol:  newSJFPolicy(defaultRunQueue),
+				opts: simOptions{tiesByPID: true},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 3, Start: 0, Stop: 2},
+				{PID: 7, Start: 2, Stop: 4},
+			},
+			wantWait:      1,
+			wantDecisions: 2,
+		},
+		{
+			name: "idle gap before a late arrival",
+			args: args{
+				processes: []Process{
+					{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+					{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+				},
+				pol: newPriorityPolicy(defaultRunQueue),
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{Start: 2, Stop: 5, Idle: true},
+				{PID: 2, Start: 5, Stop: 6},
+			},
+			wantIdle:      3,
+			wantDecisions: 2,
+		},
+		{
+			name: "scheduler overhead delays every dispatch",
+			args: args{processes: example, pol: &fcfsPolicy{}, opts: simOptions{overhead: 1}},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 1, Stop: 6},
+				{PID: 2, Start: 7, Stop: 16},
+				{PID: 3, Start: 17, Stop: 23},
+			},
+			wantWait:      16.0 / 3,
+			wantOverhead:  3,
+			wantDecisions: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := simulate(tt.args.processes, tt.args.pol, tt.args.opts)
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("simulate() gantt = %v, want %v", got.Gantt, tt.wantGantt)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("simulate() average wait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+			if got.Overhead != tt.wantOverhead || got.Idle != tt.wantIdle {
+				t.Errorf("simulate() overhead, idle = %v, %v, want %v, %v", got.Overhead, got.Idle, tt.wantOverhead, tt.wantIdle)
+			}
+			if got.Decisions != tt.wantDecisions {
+				t.Errorf("simulate() decisions = %v, want %v", got.Decisions, tt.wantDecisions)
+			}
+		})
+	}
+}
+
+func Test_simulate_schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+
+	got := simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{})
+
+	want := []ScheduleRow{
+		{ProcessID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 0, Turnaround: 5, Response: 0, Exit: 5},
+		{ProcessID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 8, Turnaround: 17, Response: 2, Exit: 20},
+		{ProcessID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 0, Turnaround: 6, Response: 0, Exit: 12},
+	}
+	if !reflect.DeepEqual(got.Schedule, want) {
+		t.Errorf("simulate() schedule = %v, want %v", got.Schedule, want)
+	}
+	if got.AveResponse != 2.0/3 {
+		t.Errorf("simulate() average response = %v, want %v", got.AveResponse, 2.0/3)
+	}
+}
+
+func Test_simulate_bursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Bursts: []Burst{{Duration: 2}, {IO: true, Duration: 3}, {Duration: 2}}},
+		{ProcessID: 2, BurstDuration: 4},
+	}
+
+	got := simulate(processes, &fcfsPolicy{}, simOptions{})
+
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 6}, {PID: 1, Start: 6, Stop: 8}}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("simulate() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	wantSchedule := []ScheduleRow{
+		{ProcessID: 1, Burst: 4, IO: 3, Wait: 1, Turnaround: 8, Exit: 8},
+		{ProcessID: 2, Burst: 4, Wait: 2, Turnaround: 6, Response: 2, Exit: 6},
+	}
+	if !reflect.DeepEqual(got.Schedule, wantSchedule) {
+		t.Errorf("simulate() schedule = %v, want %v", got.Schedule, wantSchedule)
+	}
+	if err := checkInvariants(processes, got); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_simulate_dependencies(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1, DependsOn: []int64{1}},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 1, DependsOn: []int64{2, 3}},
+	}
+
+	got := simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{})
+
+	// 2 and 4 are shorter than 1 but wait for it, 4 for 3 as well.
+	wantGantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 8},
+		{PID: 2, Start: 8, Stop: 9}, {PID: 4, Start: 9, Stop: 10},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("simulate() gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	if err := checkInvariants(processes, got); err != nil {
+		t.Error(err)
+	}
+	sjf, _ := lookupScheduler("sjf")
+	if ticks := simulateTicks(processes, sjf, defaultConfig()); !reflect.DeepEqual(ticks, got) {
+		t.Errorf("simulateTicks() = %v, want %v", ticks, got)
+	}
+}
+
+func Test_simulate_deadlines(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Deadline: 6},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 5, Deadline: 7},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 5},
+	}
+
+	got := simulate(processes, &fcfsPolicy{}, simOptions{})
+
+	for i, want := range []int64{0, 3, 0} {
+		if row := got.Schedule[i]; row.MissedBy != want {
+			t.Errorf("process %d missed by %d, want %d", row.ProcessID, row.MissedBy, want)
+		}
+	}
+}
+
+func Test_simulate_hooks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+		{ProcessID: 4, ArrivalTime: 25, BurstDuration: 1, Priority: 1},
+	}
+	var events []string
+	hooks := Hooks{
+		OnDispatch: func(now, pid int64) { events = append(events, fmt.Sprintf("%d dispatch %d", now, pid)) },
+		OnPreempt: func(now, pid, next int64) {
+			events = append(events, fmt.Sprintf("%d preempt %d for %d", now, pid, next))
+		},
+		OnComplete: func(now, pid int64) { events = append(events, fmt.Sprintf("%d complete %d", now, pid)) },
+		OnIdle:     func(from, to int64) { events = append(events, fmt.Sprintf("%d idle until %d", from, to)) },
+	}
+
+	simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{hooks: hooks})
+
+	want := []string{
+		"0 dispatch 1",
+		"5 complete 1",
+		"5 dispatch 2",
+		"6 preempt 2 for 3",
+		"6 dispatch 3",
+		"12 complete 3",
+		"12 dispatch 2",
+		"20 complete 2",
+		"20 idle until 25",
+		"25 dispatch 4",
+		"26 complete 4",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("simulate() hook events = %q, want %q", events, want)
+	}
+}
+
+func Benchmark_simulate(b *testing.B) {
+	processes := generateProcesses(rand.New(rand.NewSource(1)), generatorSpec{Count: 10000, MaxArrival: 20000, MaxBurst: 10, MaxPriority: 5})
+	policies := []struct {
+		name string
+		pol  func() policy
+	}{
+		{name: "fcfs", pol: func() policy { return &fcfsPolicy{} }},
+		{name: "rr", pol: func() policy { return &rrPolicy{q: 2} }},
+		{name: "sjf", pol: func() policy { return newSJFPolicy(defaultRunQueue) }},
+	}
+	for _, p := range policies {
+		p := p
+		b.Run(p.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				simulate(processes, p.pol(), simOptions{})
+			}
+		})
+	}
+}
+
+// Test_simulate_allocs guards the allocation-free decision loop: the
+// count must not grow with the number of processes or decisions.
+func Test_simulate_allocs(t *testing.T) {
+	processes := generateProcesses(rand.New(rand.NewSource(1)), generatorSpec{Count: 1000, MaxArrival: 2000, MaxBurst: 10, MaxPriority: 5})
+	for _, tt := range []struct {
+		name string
+		pol  func() policy
+	}{
+		{name: "fcfs", pol: func() policy { return &fcfsPolicy{} }},
+		{name: "rr", pol: func() policy { return &rrPolicy{q: 2} }},
+	} {
+		allocs := testing.AllocsPerRun(10, func() {
+			simulate(processes, tt.pol(), simOptions{})
+		})
+		if allocs > 100 {
+			t.Errorf("simulate(%s) = %v allocations for %d processes, want at most 100", tt.name, allocs, len(processes))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// userHZ is the rate of the clock ticks /proc reports times in, which
+// Linux fixes at 100 per second on every architecture it exposes.
+const userHZ = 100
+
+// parseProcStat reads a /proc/[pid]/stat line into a process with times
+// in ticks of length tick. Its burst is the CPU time it has used so far,
+// user and system, and its arrival its start time after boot. The
+// command, in parentheses, may itself contain spaces and parentheses.
+func parseProcStat(line string, tick time.Duration) (Process, error) {
+	open, closing := strings.IndexByte(line, '('), strings.LastIndexByte(line, ')')
+	if open < 0 || closing < open {
+		return Process{}, errors.New("no command in parentheses")
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(line[:open]), 10, 64)
+	if err != nil {
+		return Process{}, fmt.Errorf("process ID %q is not an integer", strings.TrimSpace(line[:open]))
+	}
+	// Fields from the state, the third, on.
+	rest := strings.Fields(line[closing+1:])
+	if len(rest) < 20 {
+		return Process{}, fmt.Errorf("want at least 22 fields, got %d", len(rest)+2)
+	}
+	field := func(n int) (int64, error) {
+		v, err := strconv.ParseInt(rest[n-3], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("field %d is not an integer, got %q", n, rest[n-3])
+		}
+		return v, nil
+	}
+	var v [4]int64 // utime, stime, nice, starttime
+	for i, n := range []int{14, 15, 19, 22} {
+		if v[i], err = field(n); err != nil {
+			return Process{}, err
+		}
+	}
+	clock := func(ticks int64) int64 {
+		return roundTicks(time.Duration(ticks)*time.Second/userHZ, tick)
+	}
+	p := Process{
+		ProcessID:     pid,
+		Name:          line[open+1 : closing],
+		ArrivalTime:   clock(v[3]),
+		BurstDuration: clock(v[0] + v[1]),
+	}
+	return withNice(p, v[2], false)
+}
+
+// readProcSnapshot reads every process in fsys, a /proc file system, that
+// has used at least one tick of CPU time. Processes that exit while it is
+// read are left out.
+func readProcSnapshot(fsys fs.FS, tick time.Duration) ([]Process, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var processes []Process
+	for _, e := range entries {
+		if _, err := strconv.ParseInt(e.Name(), 10, 64); err != nil || !e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, e.Name()+"/stat")
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseProcStat(string(data), tick)
+		if err != nil {
+			return nil, fmt.Errorf("%s/stat: %v", e.Name(), err)
+		}
+		if p.BurstDuration > 0 {
+			processes = append(processes, p)
+		}
+	}
+	return importedProcesses(processes), nil
+}
+
+// snapshot samples the processes running on this machine from /proc and
+// writes them as a workload CSV.
+func snapshot(w io.Writer, args ...string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	tick := flags.Duration("tick", time.Second/userHZ, "wall-clock length of a tick, the resolution of the workload")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: snapshot takes no positional arguments", ErrInvalidArgs)
+	}
+	if *tick <= 0 {
+		return fmt.Errorf("%w: tick must be positive, got %v", ErrInvalidArgs, *tick)
+	}
+
+	processes, err := readProcSnapshot(os.DirFS("/proc"), *tick)
+	if err != nil {
+		return fmt.Errorf("%v: error reading /proc, which only Linux provides", err)
+	}
+	if len(processes) == 0 {
+		return errors.New("no process in /proc has used any CPU time")
+	}
+	return writeProcesses(w, processes)
+}
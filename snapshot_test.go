@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_readProcSnapshot(t *testing.T) {
+	t.Parallel()
+	stat := func(line string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(line)} }
+	fsys := fstest.MapFS{
+		"1/stat":    stat("1 (systemd) S 0 1 1 0 -1 4194560 100 200 10 20 150 50 0 0 20 0 1 0 10 1000 100 0 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0\n"),
+		"42/stat":   stat("42 (Web (Content)) R 1 42 42 0 -1 0 0 0 0 0 30 0 0 0 30 10 1 0 400 1000 100 0 0\n"),
+		"43/stat":   stat("43 (kthreadd) S 2 0 0 0 -1 0 0 0 0 0 0 0 0 0 20 0 1 0 2 0 0 0 0\n"),
+		"self/stat": stat("not a process"),
+		"uptime":    stat("1000.00 900.00\n"),
+	}
+	got, err := readProcSnapshot(fsys, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Process{
+		{ProcessID: 1, Name: "systemd", ArrivalTime: 0, BurstDuration: 200, Priority: 20, Weight: 1024},
+		{ProcessID: 42, Name: "Web (Content)", ArrivalTime: 390, BurstDuration: 30, Priority: 30, Nice: 10, Weight: 110},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readProcSnapshot() = %v, want %v", got, want)
+	}
+
+	if _, err := readProcSnapshot(fstest.MapFS{"7/stat": stat("7 (short) S 1 2 3\n")}, 10*time.Millisecond); err == nil {
+		t.Error("readProcSnapshot() of a truncated stat line succeeded, want an error")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortKey orders schedule table rows by one column. The zero value keeps
+// the simulator's arrival order.
+type sortKey struct {
+	column *scheduleColumn
+	desc   bool
+}
+
+// parseSortKey parses a --sort-by value such as "wait" or "exit:desc".
+func parseSortKey(spec string) (sortKey, error) {
+	if spec == "" {
+		return sortKey{}, nil
+	}
+	name, order, _ := strings.Cut(strings.ToLower(spec), ":")
+	c, ok := lookupScheduleColumn(name)
+	if !ok {
+		return sortKey{}, fmt.Errorf("unknown sort column %q, want one of %s", name, scheduleColumnNames())
+	}
+	key := sortKey{column: &c}
+	switch order {
+	case "", "asc":
+	case "desc":
+		key.desc = true
+	default:
+		return sortKey{}, fmt.Errorf("unknown sort order %q, want asc or desc", order)
+	}
+	return key, nil
+}
+
+// sort returns rows ordered by the key, keeping ties in arrival order.
+func (k sortKey) sort(rows []ScheduleRow) []ScheduleRow {
+	if k.column == nil {
+		return rows
+	}
+	sorted := append([]ScheduleRow(nil), rows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if text := k.column.Text; text != nil {
+			ti, tj := text(sorted[i]), text(sorted[j])
+			if k.desc {
+				return ti > tj
+			}
+			return ti < tj
+		}
+		vi, vj := k.column.Value(sorted[i]), k.column.Value(sorted[j])
+		if k.desc {
+			return vi > vj
+		}
+		return vi < vj
+	})
+	return sorted
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_sortKey(t *testing.T) {
+	t.Parallel()
+	rows := []ScheduleRow{
+		{ProcessID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 0, Turnaround: 5, Exit: 5},
+		{ProcessID: 2, Priority: 1, Burst: 9, Arrival: 1, Wait: 4, Turnaround: 13, Exit: 14},
+		{ProcessID: 3, Priority: 3, Burst: 3, Arrival: 3, Wait: 2, Turnaround: 5, Exit: 8},
+	}
+	tests := []struct {
+		name    string
+		spec    string
+		wantIDs []int64
+		wantErr bool
+	}{
+		{name: "arrival order by default", spec: "", wantIDs: []int64{1, 2, 3}},
+		{name: "ascending", spec: "wait", wantIDs: []int64{1, 3, 2}},
+		{name: "descending", spec: "Burst:desc", wantIDs: []int64{2, 1, 3}},
+		{name: "ties keep arrival order", spec: "turnaround", wantIDs: []int64{1, 3, 2}},
+		{name: "response", spec: "response:desc", wantIDs: []int64{1, 2, 3}},
+		{name: "unknown column", spec: "weight", wantErr: true},
+		{name: "unknown order", spec: "wait:up", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			key, err := parseSortKey(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSortKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var ids []int64
+			for _, row := range key.sort(rows) {
+				ids = append(ids, row.ProcessID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("sort() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const stepHelp = `commands:
+  <enter>          next decision
+  continue, c      run to the end without pausing
+  until t=N, u N   pause again at the first decision at or after time N
+  help, h          show this help`
+
+// stepper pauses a simulation after every scheduling decision, printing
+// the ready queue and the chosen process and waiting for a command. The
+// steppers of one run share a scanner, since each would otherwise buffer
+// input meant for the next.
+type stepper struct {
+	in    *bufio.Scanner
+	out   io.Writer
+	title string
+	nf    numberFormat
+	opts  loadOptions // reads the time of an until command
+	// until suppresses pausing before this simulated time; -1 never pauses.
+	until int64
+}
+
+func newStepper(in *bufio.Scanner, out io.Writer, title string, cfg Config) *stepper {
+	return &stepper{in: in, out: out, title: title, nf: cfg.numberFormat(), opts: cfg.loadOptions()}
+}
+
+func (s *stepper) observe(d decision) {
+	if s.until < 0 || d.Time < s.until {
+		return
+	}
+
+	_, _ = fmt.Fprintf(s.out, "[%s] t=%s\n", s.title, s.nf.at(d.Time))
+	_, _ = fmt.Fprintf(s.out, "  ready:   %s\n", describeTasks(d.Ready))
+	if d.Previous != nil {
+		_, _ = fmt.Fprintf(s.out, "  running: %s\n", describeTasks([]*task{d.Previous}))
+	}
+	_, _ = fmt.Fprintf(s.out, "  chosen:  %d (%s)\n", d.Chosen.ProcessID, d.Reason)
+
+	for {
+		_, _ = fmt.Fprint(s.out, "step> ")
+		if !s.in.Scan() {
+			// Input closed: finish the simulation without pausing.
+			s.until = -1
+			_, _ = fmt.Fprintln(s.out)
+			return
+		}
+		cmd := strings.TrimSpace(s.in.Text())
+		switch {
+		case cmd == "":
+			return
+		case cmd == "continue" || cmd == "c":
+			s.until = -1
+			return
+		case strings.HasPrefix(cmd, "until ") || strings.HasPrefix(cmd, "u "):
+			arg := strings.TrimPrefix(strings.TrimSpace(cmd[strings.Index(cmd, " "):]), "t=")
+			t, err := parseTicks(arg, s.opts)
+			if err != nil {
+				_, _ = fmt.Fprintf(s.out, "bad time: %v\n", err)
+				continue
+			}
+			s.until = t
+			return
+		default:
+			_, _ = fmt.Fprintln(s.out, stepHelp)
+		}
+	}
+}
+
+// describeTasks lists PIDs with their remaining time and priority.
+func describeTasks(tasks []*task) string {
+	if len(tasks) == 0 {
+		return "(empty)"
+	}
+	parts := make([]string, len(tasks))
+	for i, t := range tasks {
+		parts[i] = fmt.Sprintf("%d (remaining %d, priority %d)", t.ProcessID, t.remaining, t.Priority)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_stepper(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		input     string
+		wantPause []string
+		wantSkip  []string
+	}{
+		{
+			name:      "enter steps through every decision",
+			input:     "\n\n\n\n\n",
+			wantPause: []string{"t=0", "t=3", "t=5", "t=6", "t=12"},
+		},
+		{
+			name:      "until skips ahead",
+			input:     "until t=6\n\n\n",
+			wantPause: []string{"t=0", "t=6", "t=12"},
+			wantSkip:  []string{"t=3", "t=5"},
+		},
+		{
+			name:      "continue stops pausing",
+			input:     "c\n",
+			wantPause: []string{"t=0"},
+			wantSkip:  []string{"t=3"},
+		},
+		{
+			name:      "closed input stops pausing",
+			wantPause: []string{"t=0"},
+			wantSkip:  []string{"t=3"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			s := newStepper(bufio.NewScanner(strings.NewReader(tt.input)), &w, "SJF", Config{})
+			simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{observer: s.observe})
+
+			got := w.String()
+			for _, want := range tt.wantPause {
+				if !strings.Contains(got, "[SJF] "+want+"\n") {
+					t.Errorf("stepper output = %v, want a pause at %s", got, want)
+				}
+			}
+			for _, skip := range tt.wantSkip {
+				if strings.Contains(got, "[SJF] "+skip+"\n") {
+					t.Errorf("stepper output = %v, want no pause at %s", got, skip)
+				}
+			}
+		})
+	}
+}
+
+func Test_stepper_sharedInput(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var w bytes.Buffer
+	in := bufio.NewScanner(strings.NewReader("c\nuntil t=1.2\nc\n"))
+	cfg := Config{Resolution: 5}
+	fcfs := newStepper(in, &w, "FCFS", cfg)
+	simulate(processes, &fcfsPolicy{}, simOptions{observer: fcfs.observe})
+	sjf := newStepper(in, &w, "SJF", cfg)
+	simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{observer: sjf.observe})
+
+	got := w.String()
+	for _, want := range []string{"[FCFS] t=0\n", "[SJF] t=0\n", "[SJF] t=1.2\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("stepper output = %v, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// streamHeaderEvery is how many rows a streamed table prints between
+// repeats of its header, so the columns stay labelled while scrolling.
+const streamHeaderEvery = 50
+
+// streamTable writes a bordered table one row at a time. Unlike
+// tablewriter it never holds the rows, so the column widths must be known
+// up front.
+type streamTable struct {
+	w      io.Writer
+	header []string
+	widths []int
+	every  int
+	rows   int
+}
+
+func newStreamTable(w io.Writer, header []string, widths []int, every int) *streamTable {
+	return &streamTable{w: w, header: header, widths: widths, every: every}
+}
+
+func (t *streamTable) rule() {
+	var b strings.Builder
+	b.WriteByte('+')
+	for _, width := range t.widths {
+		b.WriteString(strings.Repeat("-", width+2))
+		b.WriteByte('+')
+	}
+	_, _ = fmt.Fprintln(t.w, b.String())
+}
+
+// line writes one line of cells, right-aligned or centered. When open is
+// set, the border after each cell it flags is left out, the way
+// tablewriter merges empty footer cells.
+func (t *streamTable) line(cells []string, center bool, open []bool) {
+	var b strings.Builder
+	b.WriteByte('|')
+	for i, width := range t.widths {
+		pad := width - utf8.RuneCountInString(cells[i])
+		left := pad
+		if center {
+			left = pad / 2
+		}
+		b.WriteByte(' ')
+		b.WriteString(strings.Repeat(" ", left))
+		b.WriteString(cells[i])
+		b.WriteString(strings.Repeat(" ", pad-left))
+		if open != nil && open[i] {
+			b.WriteString("  ")
+		} else {
+			b.WriteString(" |")
+		}
+	}
+	_, _ = fmt.Fprintln(t.w, b.String())
+}
+
+func (t *streamTable) writeHeader() {
+	t.rule()
+	t.line(t.header, true, nil)
+	t.rule()
+}
+
+// append writes one row, preceded by the header on the first row and
+// every t.every rows after that.
+func (t *streamTable) append(cells []string) {
+	if t.rows == 0 || (t.every > 0 && t.rows%t.every == 0) {
+		t.writeHeader()
+	}
+	t.line(cells, false, nil)
+	t.rows++
+}
+
+// close ends the table with a footer whose cells may span several lines.
+func (t *streamTable) close(footer []string) {
+	if t.rows == 0 {
+		t.writeHeader()
+	}
+	t.rule()
+	split := make([][]string, len(footer))
+	empty := make([]bool, len(footer))
+	height := 0
+	for i, cell := range footer {
+		empty[i] = cell == ""
+		split[i] = strings.Split(cell, "\n")
+		if len(split[i]) > height {
+			height = len(split[i])
+		}
+	}
+	line := make([]string, len(footer))
+	for l := 0; l < height; l++ {
+		for i := range split {
+			line[i] = ""
+			if l < len(split[i]) {
+				line[i] = split[i][l]
+			}
+		}
+		t.line(line, true, empty)
+	}
+	t.rule()
+}
+
+// streamSchedule writes the schedule table row by row, sizing the columns
+// in a first pass that formats and discards each cell.
+func streamSchedule(w io.Writer, rows []ScheduleRow, columns []scheduleColumn, header, footer []string, nf numberFormat) {
+	widths := make([]int, len(columns))
+	for i := range columns {
+		widths[i] = utf8.RuneCountInString(header[i])
+		for _, l := range strings.Split(footer[i], "\n") {
+			widths[i] = max(widths[i], utf8.RuneCountInString(l))
+		}
+	}
+	for _, row := range rows {
+		for i, c := range columns {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c.format(row, nf)))
+		}
+	}
+
+	table := newStreamTable(w, header, widths, streamHeaderEvery)
+	cells := make([]string, len(columns))
+	for _, row := range rows {
+		for i, c := range columns {
+			cells[i] = c.format(row, nf)
+		}
+		table.append(cells)
+	}
+	table.close(footer)
+}
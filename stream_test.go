@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_streamTable(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	table := newStreamTable(&w, []string{"ID", "WAIT"}, []int{2, 7}, 2)
+	for _, row := range [][]string{{"1", "0"}, {"2", "4"}, {"3", "12"}} {
+		table.append(row)
+	}
+	table.close([]string{"", "AVERAGE\n5.33"})
+
+	want := strings.Join([]string{
+		"+----+---------+",
+		"| ID |  WAIT   |",
+		"+----+---------+",
+		"|  1 |       0 |",
+		"|  2 |       4 |",
+		"+----+---------+",
+		"| ID |  WAIT   |",
+		"+----+---------+",
+		"|  3 |      12 |",
+		"+----+---------+",
+		"|      AVERAGE |",
+		"|       5.33   |",
+		"+----+---------+",
+		"",
+	}, "\n")
+	if got := w.String(); got != want {
+		t.Errorf("streamTable =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_streamSchedule_matchesTable(t *testing.T) {
+	t.Parallel()
+	r := simulate([]Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}, &rrPolicy{q: 2}, simOptions{})
+
+	var buffered, streamed bytes.Buffer
+	outputSchedule(&buffered, r, outputOptions{})
+	outputSchedule(&streamed, r, outputOptions{stream: true})
+	if buffered.String() != streamed.String() {
+		t.Errorf("streamed table =\n%s\nwant\n%s", streamed.String(), buffered.String())
+	}
+}
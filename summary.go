@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// summaryMetric is one column of the cross-algorithm comparison. Format
+// renders a value, which is in ticks, in the chosen time unit.
+type summaryMetric struct {
+	Header string
+	Value  func(r Result) float64
+	Format func(v float64, u numberFormat) string
+	// HigherIsBetter flips which value is highlighted as best.
+	HigherIsBetter bool
+}
+
+var summaryMetrics = []summaryMetric{
+	{Header: "Avg wait", Value: func(r Result) float64 { return r.AveWait }, Format: formatDuration},
+	{Header: "Avg turnaround", Value: func(r Result) float64 { return r.AveTurnaround }, Format: formatDuration},
+	{Header: "Throughput", Value: func(r Result) float64 { return r.AveThroughput }, Format: formatRate, HigherIsBetter: true},
+	{Header: "Context switches", Value: func(r Result) float64 { return float64(r.ContextSwitches) }, Format: formatCount},
+	{Header: "Makespan", Value: func(r Result) float64 { return float64(r.Makespan) }, Format: formatTime},
+}
+
+func formatDuration(v float64, nf numberFormat) string { return nf.duration(v) }
+
+func formatRate(v float64, nf numberFormat) string { return nf.rate(v) }
+
+func formatCount(v float64, nf numberFormat) string { return nf.int(int64(v)) }
+
+func formatTime(v float64, nf numberFormat) string { return nf.time(int64(v)) }
+
+// summaryRow is one algorithm's line in the comparison.
+type summaryRow struct {
+	Scheduler Scheduler
+	Result    Result
+}
+
+// summaryCells lays the comparison out as a grid of strings, header row
+// first, alongside a parallel grid flagging the best value per column.
+func summaryCells(rows []summaryRow, nf numberFormat) ([][]string, [][]bool) {
+	header := []string{nf.header("Algorithm")}
+	for _, m := range summaryMetrics {
+		header = append(header, nf.header(m.Header))
+	}
+	cells := [][]string{header}
+	for _, row := range rows {
+		line := []string{row.Scheduler.Name}
+		for _, m := range summaryMetrics {
+			line = append(line, m.Format(m.Value(row.Result), nf))
+		}
+		cells = append(cells, line)
+	}
+	return cells, summaryExtremes(rows, false)
+}
+
+// summaryExtremes flags, in a grid parallel to summaryCells, the best
+// value per column, or the worst with worst set.
+func summaryExtremes(rows []summaryRow, worst bool) [][]bool {
+	flags := make([][]bool, len(rows)+1)
+	for i := range flags {
+		flags[i] = make([]bool, len(summaryMetrics)+1)
+	}
+	for c, m := range summaryMetrics {
+		higher := m.HigherIsBetter != worst
+		for i := range rows {
+			v := m.Value(rows[i].Result)
+			extreme := true
+			for j := range rows {
+				other := m.Value(rows[j].Result)
+				if (higher && other > v) || (!higher && other < v) {
+					extreme = false
+					break
+				}
+			}
+			flags[i+1][c+1] = extreme
+		}
+	}
+	return flags
+}
+
+// outputComparison prints one row per algorithm with the best value in
+// each column marked, in bold green when color is enabled, which also
+// paints the worst values red where the algorithms differ.
+func outputComparison(w io.Writer, rows []summaryRow, opts outputOptions) {
+	cells, best := summaryCells(rows, opts.numbers)
+	worst := summaryExtremes(rows, true)
+	for r := 1; r < len(cells); r++ {
+		for c := 1; c < len(cells[r]); c++ {
+			switch {
+			case best[r][c] && opts.color:
+				cells[r][c] = ansiBest + cells[r][c] + " *" + ansiReset
+			case best[r][c]:
+				cells[r][c] += " *"
+			case worst[r][c] && opts.color:
+				cells[r][c] = ansiWorst + cells[r][c] + ansiReset
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Comparison (* best per column)")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(cells[0])
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.AppendBulk(cells[1:])
+	table.Render()
+}
+
+// writeSummaryImage renders the comparison to path, as SVG or PNG
+// depending on its extension.
+func writeSummaryImage(path string, rows []summaryRow, nf numberFormat) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%v: error creating summary image", err)
+	}
+	defer f.Close()
+
+	cells, best := summaryCells(rows, nf)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		err = writeSummaryPNG(f, cells, best)
+	default:
+		err = writeSummarySVG(f, cells, best)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+const (
+	summaryCellPadding = 12
+	summaryRowHeight   = 28
+)
+
+var (
+	summaryBestFill   = color.RGBA{R: 0xc7, G: 0xe9, B: 0xc0, A: 0xff}
+	summaryHeaderFill = color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff}
+	summaryGridColor  = color.RGBA{R: 0x99, G: 0x99, B: 0x99, A: 0xff}
+)
+
+// summaryColumnWidths sizes each column to its longest cell, given the
+// pixel width of one character.
+func summaryColumnWidths(cells [][]string, charWidth int) []int {
+	widths := make([]int, len(cells[0]))
+	for _, row := range cells {
+		for c, cell := range row {
+			if w := len(cell)*charWidth + 2*summaryCellPadding; w > widths[c] {
+				widths[c] = w
+			}
+		}
+	}
+	return widths
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func writeSummarySVG(w io.Writer, cells [][]string, best [][]bool) error {
+	const charWidth = 9
+	widths := summaryColumnWidths(cells, charWidth)
+	total := 0
+	for _, cw := range widths {
+		total += cw
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="14">`+"\n",
+		total+1, len(cells)*summaryRowHeight+1)
+	for r, row := range cells {
+		x, y := 0, r*summaryRowHeight
+		for c, cell := range row {
+			fill, weight := "#ffffff", "normal"
+			switch {
+			case r == 0:
+				fill, weight = hexColor(summaryHeaderFill), "bold"
+			case best[r][c]:
+				fill, weight = hexColor(summaryBestFill), "bold"
+			}
+			fmt.Fprintf(&b, `  <rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s"/>`+"\n",
+				x, y, widths[c], summaryRowHeight, fill, hexColor(summaryGridColor))
+			fmt.Fprintf(&b, `  <text x="%d" y="%d" font-weight="%s">%s</text>`+"\n",
+				x+summaryCellPadding, y+summaryRowHeight-9, weight, html.EscapeString(cell))
+			x += widths[c]
+		}
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeSummaryPNG(w io.Writer, cells [][]string, best [][]bool) error {
+	face := basicfont.Face7x13
+	widths := summaryColumnWidths(cells, face.Advance)
+	total := 0
+	for _, cw := range widths {
+		total += cw
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, total+1, len(cells)*summaryRowHeight+1))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	d := font.Drawer{Dst: img, Src: image.Black, Face: face}
+	for r, row := range cells {
+		x, y := 0, r*summaryRowHeight
+		for c, cell := range row {
+			rect := image.Rect(x, y, x+widths[c], y+summaryRowHeight)
+			switch {
+			case r == 0:
+				draw.Draw(img, rect, image.NewUniform(summaryHeaderFill), image.Point{}, draw.Src)
+			case best[r][c]:
+				draw.Draw(img, rect, image.NewUniform(summaryBestFill), image.Point{}, draw.Src)
+			}
+			strokeRect(img, rect, summaryGridColor)
+			d.Dot = fixed.P(x+summaryCellPadding, y+summaryRowHeight-9)
+			d.DrawString(cell)
+			x += widths[c]
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// strokeRect draws the one pixel outline of r, including its max edges.
+func strokeRect(img draw.Image, r image.Rectangle, c color.Color) {
+	for x := r.Min.X; x <= r.Max.X; x++ {
+		img.Set(x, r.Min.Y, c)
+		img.Set(x, r.Max.Y, c)
+	}
+	for y := r.Min.Y; y <= r.Max.Y; y++ {
+		img.Set(r.Min.X, y, c)
+		img.Set(r.Max.X, y, c)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_summaryCells(t *testing.T) {
+	t.Parallel()
+	rows := []summaryRow{
+		{Scheduler: Scheduler{Name: "fcfs"}, Result: Result{AveWait: 3, AveTurnaround: 10, AveThroughput: 0.15, ContextSwitches: 2, Makespan: 20}},
+		{Scheduler: Scheduler{Name: "sjf"}, Result: Result{AveWait: 2, AveTurnaround: 9, AveThroughput: 0.15, ContextSwitches: 3, Makespan: 20}},
+		{Scheduler: Scheduler{Name: "rr"}, Result: Result{AveWait: 5, AveTurnaround: 11, AveThroughput: 0.1, ContextSwitches: 8, Makespan: 21}},
+	}
+
+	cells, best := summaryCells(rows, numberFormat{})
+
+	wantCells := [][]string{
+		{"Algorithm", "Avg wait", "Avg turnaround", "Throughput", "Context switches", "Makespan"},
+		{"fcfs", "3.00", "10.00", "0.15/t", "2", "20"},
+		{"sjf", "2.00", "9.00", "0.15/t", "3", "20"},
+		{"rr", "5.00", "11.00", "0.10/t", "8", "21"},
+	}
+	if !reflect.DeepEqual(cells, wantCells) {
+		t.Errorf("summaryCells() cells = %v, want %v", cells, wantCells)
+	}
+	wantBest := [][]bool{
+		{false, false, false, false, false, false},
+		{false, false, false, true, true, true},
+		{false, true, true, true, false, true},
+		{false, false, false, false, false, false},
+	}
+	if !reflect.DeepEqual(best, wantBest) {
+		t.Errorf("summaryCells() best = %v, want %v", best, wantBest)
+	}
+}
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	rows := []summaryRow{
+		{Scheduler: Scheduler{Name: "fcfs"}, Result: Result{AveWait: 3, AveTurnaround: 10, AveThroughput: 0.15, ContextSwitches: 2, Makespan: 20}},
+		{Scheduler: Scheduler{Name: "rr"}, Result: Result{AveWait: 5, AveTurnaround: 11, AveThroughput: 0.1, ContextSwitches: 8, Makespan: 20}},
+	}
+
+	var b strings.Builder
+	outputComparison(&b, rows, outputOptions{})
+
+	for _, want := range []string{
+		"|      fcfs |   3.00 * |        10.00 * |   0.15/t * |              2 * |     20 * |",
+		"|        rr |     5.00 |          11.00 |     0.10/t |                8 |     20 * |",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("outputComparison() missing row %q in\n%s", want, b.String())
+		}
+	}
+
+	b.Reset()
+	outputComparison(&b, rows, outputOptions{color: true})
+	for _, want := range []string{ansiBest + "3.00 *" + ansiReset, ansiWorst + "5.00" + ansiReset, ansiBest + "20 *" + ansiReset} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("outputComparison() missing cell %q in\n%s", want, b.String())
+		}
+	}
+	if strings.Contains(b.String(), ansiWorst+"20") {
+		t.Errorf("outputComparison() marked a value every algorithm shares as worst:\n%s", b.String())
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+var ErrInvalidSweep = errors.New("invalid sweep")
+
+// maxSweepSteps bounds how many values a sweep may take, so a range typed
+// too wide is reported rather than run for hours or run out of memory.
+const maxSweepSteps = 10_000
+
+// sweep is a parsed --sweep specification: one Config knob and the values
+// it should take, e.g. "quantum=1..10" or "quantum=1,2,4,8".
+type sweep struct {
+	Param  string
+	Values []int64
+}
+
+// sweepParams maps each sweepable knob onto the Config field it sets.
+var sweepParams = map[string]func(cfg *Config, v int64){
+	"quantum":        func(cfg *Config, v int64) { cfg.Quantum = v },
+	"sched_overhead": func(cfg *Config, v int64) { cfg.SchedOverhead = v },
+}
+
+func parseSweep(spec string) (sweep, error) {
+	param, values, ok := strings.Cut(spec, "=")
+	if !ok {
+		return sweep{}, fmt.Errorf("%w: %q must look like name=lo..hi", ErrInvalidSweep, spec)
+	}
+	if _, ok := sweepParams[param]; !ok {
+		return sweep{}, fmt.Errorf("%w: %q is not a sweepable parameter", ErrInvalidSweep, param)
+	}
+
+	sw := sweep{Param: param}
+	if lo, hi, ok := strings.Cut(values, ".."); ok {
+		from, err := strconv.ParseInt(lo, 10, 64)
+		if err != nil {
+			return sweep{}, fmt.Errorf("%w: %v", ErrInvalidSweep, err)
+		}
+		to, err := strconv.ParseInt(hi, 10, 64)
+		if err != nil {
+			return sweep{}, fmt.Errorf("%w: %v", ErrInvalidSweep, err)
+		}
+		if from > to {
+			return sweep{}, fmt.Errorf("%w: empty range %d..%d", ErrInvalidSweep, from, to)
+		}
+		if uint64(to)-uint64(from) >= maxSweepSteps {
+			return sweep{}, fmt.Errorf("%w: range %d..%d has more than %d steps", ErrInvalidSweep, from, to, maxSweepSteps)
+		}
+		for v := from; v <= to; v++ {
+			sw.Values = append(sw.Values, v)
+		}
+		return sw, nil
+	}
+	fields := strings.Split(values, ",")
+	if len(fields) > maxSweepSteps {
+		return sweep{}, fmt.Errorf("%w: more than %d values", ErrInvalidSweep, maxSweepSteps)
+	}
+	for _, field := range fields {
+		v, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return sweep{}, fmt.Errorf("%w: %v", ErrInvalidSweep, err)
+		}
+		sw.Values = append(sw.Values, v)
+	}
+	return sw, nil
+}
+
+// runSweep reruns every selected algorithm that is tunable by the swept
+// parameter once per value, and writes the averages as a table or CSV.
+func runSweep(w io.Writer, cfg Config, processes []Process) error {
+	sw, err := parseSweep(cfg.Sweep)
+	if err != nil {
+		return err
+	}
+
+	var tunable []Scheduler
+	for _, name := range cfg.Algorithms {
+		s, _ := lookupScheduler(name)
+		for _, p := range s.Params {
+			if p == sw.Param {
+				tunable = append(tunable, s)
+			}
+		}
+	}
+	if len(tunable) == 0 {
+		return fmt.Errorf("%w: no selected algorithm is tunable by %s", ErrInvalidSweep, sw.Param)
+	}
+
+	nf := cfg.numberFormat()
+	rows := make([][]string, 0, len(sw.Values)*len(tunable))
+	for _, v := range sw.Values {
+		c := cfg
+		sweepParams[sw.Param](&c, v)
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("%w: %s=%d: %v", ErrInvalidSweep, sw.Param, v, err)
+		}
+		for _, s := range tunable {
+			r := s.Schedule(processes, c)
+			rows = append(rows, []string{
+				fmt.Sprint(v),
+				s.Name,
+				nf.duration(r.AveWait),
+				nf.duration(r.AveTurnaround),
+			})
+		}
+	}
+
+	header := []string{sw.Param, "algorithm", "avg wait", "avg turnaround"}
+	if cfg.SweepFormat == "csv" {
+		cw := csv.NewWriter(w)
+		_ = cw.Write(header)
+		_ = cw.WriteAll(rows)
+		return cw.Error()
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.Render()
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSweep(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    sweep
+		wantErr error
+	}{
+		{
+			name: "range",
+			spec: "quantum=1..4",
+			want: sweep{Param: "quantum", Values: []int64{1, 2, 3, 4}},
+		},
+		{
+			name: "list",
+			spec: "quantum=1,2,8",
+			want: sweep{Param: "quantum", Values: []int64{1, 2, 8}},
+		},
+		{
+			name:    "missing values",
+			spec:    "quantum",
+			wantErr: ErrInvalidSweep,
+		},
+		{
+			name:    "unknown parameter",
+			spec:    "cpus=1..4",
+			wantErr: ErrInvalidSweep,
+		},
+		{
+			name: "range at the step cap",
+			spec: "quantum=1..10000",
+			want: sweep{Param: "quantum", Values: func() []int64 {
+				values := make([]int64, maxSweepSteps)
+				for i := range values {
+					values[i] = int64(i + 1)
+				}
+				return values
+			}()},
+		},
+		{
+			name:    "range past the step cap",
+			spec:    "quantum=1..10001",
+			wantErr: ErrInvalidSweep,
+		},
+		{
+			name:    "range overflowing the step count",
+			spec:    "quantum=-9223372036854775808..9223372036854775807",
+			wantErr: ErrInvalidSweep,
+		},
+		{
+			name:    "empty range",
+			spec:    "quantum=5..1",
+			wantErr: ErrInvalidSweep,
+		},
+		{
+			name:    "bad value",
+			spec:    "quantum=1,x",
+			wantErr: ErrInvalidSweep,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSweep(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseSweep() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSweep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
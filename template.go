@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/template"
+)
+
+// templateData is what a --template report is executed with.
+type templateData struct {
+	Algorithms []templateAlgorithm
+	Warnings   []warning
+}
+
+// templateAlgorithm is one algorithm's run, its Result in full.
+type templateAlgorithm struct {
+	Name   string
+	Title  string
+	Result Result
+}
+
+// parseTemplate reads a --template report. Its functions render times in
+// ticks the way the text output does: time and at for points in time,
+// duration and rate for averages and throughput, and float for any other
+// number.
+func parseTemplate(path string, nf numberFormat) (*template.Template, error) {
+	t, err := template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"time":     nf.time,
+		"at":       nf.at,
+		"duration": nf.duration,
+		"rate":     nf.rate,
+		"float":    nf.float,
+	}).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return t, nil
+}
+
+// outputTemplate writes a run's results through the cfg.Template report.
+func outputTemplate(w io.Writer, cfg Config, warnings []warning, summary []summaryRow) error {
+	t, err := parseTemplate(cfg.Template, cfg.numberFormat())
+	if err != nil {
+		return err
+	}
+	data := templateData{Warnings: warnings, Algorithms: make([]templateAlgorithm, len(summary))}
+	for i, row := range summary {
+		data.Algorithms[i] = templateAlgorithm{Name: row.Scheduler.Name, Title: row.Scheduler.Title, Result: row.Result}
+	}
+	if err := t.Execute(w, data); err != nil {
+		return fmt.Errorf("%v: error executing template", err)
+	}
+	return nil
+}
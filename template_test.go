@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_outputTemplate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeTemplate := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	report := writeTemplate("report.tmpl",
+		"{{range .Algorithms}}{{.Name}} ({{.Title}}): wait {{duration .Result.AveWait}}"+
+			"{{range .Result.Gantt}} {{.PID}}@{{at .Start}}{{end}}\n{{end}}")
+	broken := writeTemplate("broken.tmpl", "{{.Algorithms")
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		format   string
+		want     string
+		wantErr  error
+	}{
+		{name: "report", template: report, want: "fcfs (First-come, first-serve): wait 1.00 1@0 2@3\n"},
+		{name: "broken", template: broken, wantErr: ErrInvalidConfig},
+		{name: "with a format", template: report, format: "json", wantErr: ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := defaultConfig()
+			cfg.Algorithms = []string{"fcfs"}
+			cfg.Template = tt.template
+			cfg.Format = tt.format
+			if err := cfg.validate(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			var w bytes.Buffer
+			if err := run(&w, cfg, processes); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("run() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
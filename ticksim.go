@@ -0,0 +1,281 @@
+package main
+
+import "sort"
+
+// referenceRule is how the reference engine chooses among ready processes
+// under one algorithm, written out from the algorithm's description
+// rather than taken from its policy.
+type referenceRule struct {
+	// key, when set, runs the ready process with the smallest value first,
+	// the earliest arrival breaking ties; otherwise the ready queue is
+	// first in, first out.
+	key func(p *refProcess) int64
+	// preempts hands the CPU to a ready process whose key is strictly
+	// smaller than the running one's.
+	preempts bool
+	// rotates sends the running process to the back of the ready queue
+	// once it has run for a quantum and another process is ready.
+	rotates bool
+}
+
+// referenceRules holds the reference engine's rule for every algorithm, by
+// name.
+var referenceRules = map[string]referenceRule{
+	"fcfs":     {},
+	"rr":       {rotates: true},
+	"sjf":      {key: func(p *refProcess) int64 { return p.left }, preempts: true},
+	"priority": {key: func(p *refProcess) int64 { return p.Priority }, preempts: true},
+}
+
+// refProcess is the reference engine's state for one process.
+type refProcess struct {
+	Process
+	order int   // position in arrival order
+	burst int   // index into Bursts of the CPU burst under way
+	left  int64 // of that CPU burst
+
+	arrived  bool
+	held     bool // arrived, but waiting on its dependencies
+	done     bool
+	waking   bool  // due back at wakeAt, from I/O or its dependencies
+	wakeAt   int64 // when it is due back
+	wakeSeq  int   // orders processes due back at the same time
+	started  bool
+	firstRun int64
+	exit     int64
+}
+
+// simulateTicks is a reference engine for checking simulate: it advances
+// time one tick at a time and decides afresh after every tick, with its
+// own bookkeeping and the rule referenceRules gives s, sharing nothing with
+// simulate but the Result it fills. It is slow but simple enough to trust.
+// Observers, hooks and metrics are not supported.
+func simulateTicks(processes []Process, s Scheduler, cfg Config) Result {
+	rule := referenceRules[s.Name]
+	var quantum int64
+	if rule.rotates {
+		quantum = cfg.Quantum
+	}
+
+	procs := make([]*refProcess, len(processes))
+	byPID := make(map[int64]*refProcess, len(processes))
+	for i := range processes {
+		procs[i] = &refProcess{Process: processes[i], left: processes[i].BurstDuration}
+		if len(processes[i].Bursts) > 0 {
+			procs[i].left = processes[i].Bursts[0].Duration
+		}
+	}
+	sort.SliceStable(procs, func(i, j int) bool {
+		if procs[i].ArrivalTime != procs[j].ArrivalTime {
+			return procs[i].ArrivalTime < procs[j].ArrivalTime
+		}
+		return cfg.ArrivalTies == "pid" && procs[i].ProcessID < procs[j].ProcessID
+	})
+	for i, p := range procs {
+		p.order = i
+		byPID[p.ProcessID] = p
+	}
+	// depsDone reports whether every dependency of p in the workload has
+	// completed; others are taken as met.
+	depsDone := func(p *refProcess) bool {
+		for _, pid := range p.DependsOn {
+			if d, ok := byPID[pid]; ok && d != p && !d.done {
+				return false
+			}
+		}
+		return true
+	}
+
+	var (
+		r          = Result{Gantt: []TimeSlice{}}
+		queue      []*refProcess
+		now        int64
+		done       int
+		wakeSeq    int
+		running    *refProcess
+		lastRan    *refProcess
+		sliceStart int64
+		resched    bool
+	)
+	wake := func(p *refProcess, at int64) {
+		p.waking, p.wakeAt, p.wakeSeq = true, at, wakeSeq
+		wakeSeq++
+	}
+	// admit queues the arrivals due by now, then the processes due back,
+	// in the order they were sent away.
+	admit := func() {
+		for _, p := range procs {
+			if !p.arrived && p.ArrivalTime <= now {
+				p.arrived = true
+				if p.held = !depsDone(p); !p.held {
+					queue = append(queue, p)
+				}
+			}
+		}
+		var back []*refProcess
+		for _, p := range procs {
+			if p.waking && p.wakeAt <= now {
+				back = append(back, p)
+			}
+		}
+		sort.Slice(back, func(i, j int) bool {
+			if back[i].wakeAt != back[j].wakeAt {
+				return back[i].wakeAt < back[j].wakeAt
+			}
+			return back[i].wakeSeq < back[j].wakeSeq
+		})
+		for _, p := range back {
+			p.waking = false
+			queue = append(queue, p)
+		}
+	}
+	// due reports whether a process arrives or is back by now.
+	due := func() bool {
+		for _, p := range procs {
+			if (!p.arrived && p.ArrivalTime <= now) || (p.waking && p.wakeAt <= now) {
+				return true
+			}
+		}
+		return false
+	}
+	// take removes the process the rule runs next from the ready queue.
+	take := func() *refProcess {
+		i := 0
+		if rule.key != nil {
+			for j, p := range queue {
+				if k, m := rule.key(p), rule.key(queue[i]); k < m || (k == m && p.order < queue[i].order) {
+					i = j
+				}
+			}
+		}
+		p := queue[i]
+		queue = append(queue[:i:i], queue[i+1:]...)
+		return p
+	}
+	pick := func() *refProcess {
+		switch {
+		case running == nil:
+			return take()
+		case len(queue) == 0:
+			return running
+		case rule.rotates:
+			queue = append(queue, running)
+			return take()
+		case rule.preempts:
+			next := take()
+			if rule.key(next) < rule.key(running) {
+				queue = append(queue, running)
+				return next
+			}
+			queue = append(queue, next)
+		}
+		return running
+	}
+
+	for done < len(procs) {
+		admit()
+		if running == nil && len(queue) == 0 {
+			if n := len(r.Gantt); n == 0 || !r.Gantt[n-1].Idle {
+				r.Gantt = append(r.Gantt, TimeSlice{Start: now, Stop: now, Idle: true})
+			}
+			now++
+			r.Gantt[len(r.Gantt)-1].Stop = now
+			continue
+		}
+
+		if running == nil || resched {
+			resched = false
+			r.Decisions++
+			for i := int64(0); i < cfg.SchedOverhead; i++ {
+				now++
+				r.Overhead++
+				admit()
+			}
+			running = pick()
+			if lastRan != nil && lastRan != running {
+				r.ContextSwitches++
+			}
+			if !running.started {
+				running.started, running.firstRun = true, now
+			}
+			if n := len(r.Gantt); n == 0 || r.Gantt[n-1].Idle || r.Gantt[n-1].PID != running.ProcessID || r.Gantt[n-1].Stop != now {
+				r.Gantt = append(r.Gantt, TimeSlice{PID: running.ProcessID, Start: now, Stop: now})
+			}
+			lastRan, sliceStart = running, now
+		}
+
+		if running.left > 0 {
+			running.left--
+			now++
+			r.Gantt[len(r.Gantt)-1].Stop = now
+		}
+
+		switch {
+		case running.left == 0 && running.burst+1 < len(running.Bursts):
+			io := running.Bursts[running.burst+1].Duration
+			running.burst += 2
+			running.left = running.Bursts[running.burst].Duration
+			wake(running, now+io)
+			r.Blocked = append(r.Blocked, TimeSlice{PID: running.ProcessID, Start: now, Stop: now + io})
+			running = nil
+		case running.left == 0:
+			running.done, running.exit = true, now
+			done++
+			for _, p := range procs {
+				if p.held && depsDone(p) {
+					p.held = false
+					wake(p, now)
+				}
+			}
+			running = nil
+		case quantum > 0 && now-sliceStart >= quantum:
+			resched = true
+		case rule.preempts && due():
+			resched = true
+		}
+	}
+
+	referenceTotals(&r, procs)
+	return r
+}
+
+// referenceTotals fills in r's schedule table, in arrival order, and its
+// averages from the finished processes.
+func referenceTotals(r *Result, procs []*refProcess) {
+	var wait, turnaround, response, last int64
+	r.Schedule = make([]ScheduleRow, 0, len(procs))
+	for _, p := range procs {
+		row := ScheduleRow{
+			ProcessID: p.ProcessID, Name: p.Name, Owner: p.Owner, Priority: p.Priority,
+			Burst: p.BurstDuration, Arrival: p.ArrivalTime, Exit: p.exit, Deadline: p.Deadline,
+			Turnaround: p.exit - p.ArrivalTime,
+			Response:   p.firstRun - p.ArrivalTime,
+		}
+		for _, b := range p.Bursts {
+			if b.IO {
+				row.IO += b.Duration
+			}
+		}
+		row.Wait = row.Turnaround - row.Burst - row.IO
+		if p.Deadline > 0 && p.exit > p.Deadline {
+			row.MissedBy = p.exit - p.Deadline
+		}
+		wait += row.Wait
+		turnaround += row.Turnaround
+		response += row.Response
+		r.Busy += row.Burst
+		last = max(last, p.exit)
+		r.Schedule = append(r.Schedule, row)
+	}
+	n := float64(len(procs))
+	r.AveWait = float64(wait) / n
+	r.AveTurnaround = float64(turnaround) / n
+	r.AveResponse = float64(response) / n
+	if last > 0 {
+		r.AveThroughput = n / float64(last)
+	}
+	r.Idle = last - r.Busy - r.Overhead
+	if len(procs) > 0 {
+		r.Makespan = last - procs[0].ArrivalTime
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// arrivalField is the workload field of arrival times, which alone can
+// also be given as timestamps.
+const arrivalField = 2
+
+// parseTimestamp reads a point in time given as RFC 3339, such as
+// "2024-05-01T14:03:05.25Z", or as Unix seconds after an @, such as
+// "@1714572185.25". ok reports whether s is meant as a timestamp at all.
+func parseTimestamp(s string) (t time.Time, ok bool, err error) {
+	s = strings.TrimSpace(s)
+	if rest, found := strings.CutPrefix(s, "@"); found {
+		secs, frac, _ := strings.Cut(rest, ".")
+		sec, err := strconv.ParseInt(secs, 10, 64)
+		var nsec uint64
+		if err == nil && frac != "" {
+			if len(frac) > 9 {
+				frac = frac[:9]
+			}
+			nsec, err = strconv.ParseUint(frac+strings.Repeat("0", 9-len(frac)), 10, 32)
+		}
+		if err != nil {
+			return time.Time{}, true, fmt.Errorf("must be Unix seconds after @, such as @1714572185.25, got %q", s)
+		}
+		return time.Unix(sec, int64(nsec)), true, nil
+	}
+	t, err = time.Parse(time.RFC3339Nano, s)
+	return t, err == nil, nil
+}
+
+// parseArrival reads an arrival time as parseTicks does, or a timestamp,
+// which is converted to the tick it falls in counting from the Unix epoch
+// and reported as stamped, to be made relative to the workload's earliest
+// arrival by arrivalStamps.
+func parseArrival(s string, opts loadOptions) (v int64, stamped bool, err error) {
+	t, stamped, err := parseTimestamp(s)
+	if !stamped {
+		v, err = parseTicks(s, opts)
+		return v, false, err
+	}
+	if err != nil {
+		return 0, true, err
+	}
+	if opts.tick <= 0 {
+		return 0, true, fmt.Errorf("%q is a timestamp, but no tick length is set (--tick)", s)
+	}
+	d, res := t.Sub(time.Unix(0, 0)), time.Duration(max(opts.resolution, 1))
+	return int64(d/opts.tick*res + d%opts.tick*res/opts.tick), true, nil
+}
+
+// arrivalStamps tracks whether a workload's arrivals are timestamps, which
+// must then be given for every process, as they cannot be ordered against
+// plain times.
+type arrivalStamps struct {
+	seen, stamped bool
+}
+
+// add records a process's arrival kind, failing if it differs from the
+// processes' before it.
+func (a *arrivalStamps) add(stamped bool) error {
+	switch {
+	case !a.seen:
+		a.seen, a.stamped = true, stamped
+	case stamped && !a.stamped:
+		return fmt.Errorf("arrival is a timestamp, but earlier arrivals are not")
+	case !stamped && a.stamped:
+		return fmt.Errorf("arrival is not a timestamp, but earlier arrivals are")
+	}
+	return nil
+}
+
+// normalize makes stamped arrivals relative to the earliest, which
+// arrives at 0.
+func (a *arrivalStamps) normalize(processes []Process) {
+	if !a.stamped || len(processes) == 0 {
+		return
+	}
+	earliest := processes[0].ArrivalTime
+	for _, p := range processes {
+		earliest = min(earliest, p.ArrivalTime)
+	}
+	for i := range processes {
+		processes[i].ArrivalTime -= earliest
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseArrival(t *testing.T) {
+	t.Parallel()
+	second := loadOptions{tick: time.Second}
+	tests := []struct {
+		name        string
+		s           string
+		opts        loadOptions
+		want        int64
+		wantStamped bool
+		wantErr     bool
+	}{
+		{name: "ticks", s: "7", want: 7},
+		{name: "rfc 3339", s: "1970-01-01T00:01:40Z", opts: second, want: 100, wantStamped: true},
+		{name: "rfc 3339 with an offset", s: "1970-01-01T01:01:40+01:00", opts: second, want: 100, wantStamped: true},
+		{name: "epoch", s: "@100", opts: second, want: 100, wantStamped: true},
+		{name: "fractional epoch falls in its tick", s: "@100.75", opts: second, want: 100, wantStamped: true},
+		{name: "epoch at a resolution", s: "@100.75", opts: loadOptions{tick: time.Second, resolution: 4}, want: 403, wantStamped: true},
+		{name: "no tick", s: "@100", wantStamped: true, wantErr: true},
+		{name: "malformed epoch", s: "@soon", opts: second, wantStamped: true, wantErr: true},
+		{name: "neither", s: "soon", opts: second, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotStamped, err := parseArrival(tt.s, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseArrival() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || gotStamped != tt.wantStamped {
+				t.Errorf("parseArrival() = %d, %v, want %d, %v", got, gotStamped, tt.want, tt.wantStamped)
+			}
+		})
+	}
+}
+
+func Test_loadWorkload_timestamps(t *testing.T) {
+	t.Parallel()
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 3, ArrivalTime: 5},
+		{ProcessID: 3, BurstDuration: 2, ArrivalTime: 20},
+	}
+	tests := []struct {
+		name    string
+		file    string
+		input   string
+		want    []Process
+		wantErr bool
+	}{
+		{
+			name:  "csv",
+			file:  "log.csv",
+			input: "pid,burst,arrival\n1,5,2024-05-01T14:03:05Z\n2,3,2024-05-01T14:03:05.250Z\n3,2,@1714572186\n",
+			want:  want,
+		},
+		{
+			name:  "yaml",
+			file:  "log.yaml",
+			input: "processes:\n  - {pid: 1, burst: 5, arrival: 2024-05-01T14:03:05Z}\n  - {pid: 2, burst: 3, arrival: \"@1714572185.25\"}\n  - {pid: 3, burst: 2, arrival: 2024-05-01T14:03:06Z}\n",
+			want:  want,
+		},
+		{
+			name:  "toml date-times",
+			file:  "log.toml",
+			input: "[[processes]]\npid = 1\nburst = 5\narrival = 2024-05-01T14:03:05Z\n\n[[processes]]\npid = 2\nburst = 3\narrival = 2024-05-01T14:03:05.25Z\n\n[[processes]]\npid = 3\nburst = 2\narrival = 2024-05-01T14:03:06Z\n",
+			want:  want,
+		},
+		{
+			name:    "timestamps mixed with times",
+			file:    "log.csv",
+			input:   "pid,burst,arrival\n1,5,2024-05-01T14:03:05Z\n2,3,4\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadWorkload(tt.file, strings.NewReader(tt.input), loadOptions{tick: 50 * time.Millisecond})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadWorkload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
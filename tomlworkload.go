@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlWorkload is the workload half of a TOML scenario file, which can
+// hold the simulation settings and the processes together:
+//
+//	algorithms = ["sjf", "rr"]
+//	quantum = 4
+//
+//	[defaults]
+//	priority = 3
+//
+//	[[processes]]
+//	pid = 1
+//	burst = 5
+//	arrival = 0
+//
+// Settings use the keys of a --config file, and are read by loadScenario,
+// and processes the field names of a CSV header row; fields a process
+// leaves out come from defaults.
+type tomlWorkload struct {
+	scenarioSettings
+	Defaults  map[string]any   `toml:"defaults"`
+	Processes []map[string]any `toml:"processes"`
+}
+
+// parseTOMLWorkload reads the processes of a TOML scenario, ignoring its
+// settings. TOML gives no line numbers for decoded values, so problems
+// name the process by its position in the file.
+func parseTOMLWorkload(r io.Reader, opts loadOptions) ([]Process, []workloadProblem) {
+	var doc tomlWorkload
+	if _, err := toml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, []workloadProblem{tomlProblem(err)}
+	}
+
+	var (
+		pids     = newPIDSet(opts.onDuplicate)
+		stamps   arrivalStamps
+		problems = make([]workloadProblem, 0)
+	)
+	defaults, msgs := fieldsByName(doc.Defaults, opts)
+	for _, msg := range msgs {
+		problems = append(problems, workloadProblem{Msg: "defaults: " + msg})
+	}
+	for i, raw := range doc.Processes {
+		fields, msgs := fieldsByName(raw, opts)
+		p, more := namedProcess(fields, defaults)
+		msgs = append(msgs, more...)
+		if len(msgs) == 0 {
+			if err := stamps.add(fields.arrivalStamped(defaults)); err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		for _, msg := range msgs {
+			problems = append(problems, workloadProblem{Msg: fmt.Sprintf("process %d: %s", i+1, msg)})
+		}
+		if len(msgs) > 0 {
+			continue
+		}
+		first, err := pids.add(p, i+1)
+		if err != nil {
+			problems = append(problems, workloadProblem{Msg: fmt.Sprintf("process %d: %v", i+1, err)})
+		}
+		if first > 0 {
+			problems = append(problems, workloadProblem{
+				Msg: fmt.Sprintf("process %d: duplicate process ID %d, first defined by process %d", i+1, p.ProcessID, first),
+			})
+		}
+	}
+
+	return settleWorkload(pids, &stamps, problems, opts.seed)
+}
+
+// tomlProblem reports a TOML decoding error, on its line when it is a
+// syntax error.
+func tomlProblem(err error) workloadProblem {
+	var perr toml.ParseError
+	if errors.As(err, &perr) {
+		return workloadProblem{Line: perr.Position.Line, Msg: perr.Message}
+	}
+	return workloadProblem{Msg: err.Error()}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseTOMLWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         string
+		wantProcesses []Process
+		wantProblems  []string
+	}{
+		{
+			name: "settings ignored and defaults applied",
+			input: `algorithms = ["rr"]
+quantum = 3
+
+[defaults]
+priority = 7
+
+[[processes]]
+pid = 1
+burst = 5
+arrival = 0
+
+[[processes]]
+id = 2
+burst = 1
+arrival = 4
+priority = 1
+`,
+			wantProcesses: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 7},
+				{ProcessID: 2, BurstDuration: 1, ArrivalTime: 4, Priority: 1},
+			},
+			wantProblems: []string{},
+		},
+		{
+			name: "every problem reported with its process",
+			input: `processes = [
+  {pid = 1, burst = 5},
+  {pid = 2, burst = -1, arrival = 0},
+  {pid = 3, burst = 1, arrival = 0, colour = 9},
+  {pid = 4, burst = 1, arrival = 0},
+  {pid = 4, burst = 1, arrival = 2},
+]
+`,
+			wantProcesses: []Process{{ProcessID: 4, BurstDuration: 1}},
+			wantProblems: []string{
+				"process 1: missing arrival time",
+				"process 2: burst duration must not be negative, got -1",
+				`process 3: unknown field "colour", want pid, name, owner, burst, arrival, priority, nice, weight, deadline, period, jitter, bursts, affinity or depends_on`,
+				"process 5: duplicate process ID 4, first defined by process 4",
+			},
+		},
+		{
+			name: "nice outranks a default priority",
+			input: `[defaults]
+priority = 3
+
+[[processes]]
+pid = 1
+burst = 2
+arrival = 0
+nice = 19
+`,
+			wantProcesses: []Process{{ProcessID: 1, BurstDuration: 2, Priority: 39, Nice: 19, Weight: 15}},
+			wantProblems:  []string{},
+		},
+		{
+			name:         "syntax error",
+			input:        "[[processes]]\npid = \n",
+			wantProblems: []string{"line 2: expected value but found '\\n' instead"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, problems := parseTOMLWorkload(strings.NewReader(tt.input), loadOptions{})
+			got := make([]string, len(problems))
+			for i, p := range problems {
+				got[i] = p.String()
+			}
+			if !reflect.DeepEqual(got, tt.wantProblems) {
+				t.Errorf("parseTOMLWorkload() problems = %q, want %q", got, tt.wantProblems)
+			}
+			if !reflect.DeepEqual(processes, tt.wantProcesses) {
+				t.Errorf("parseTOMLWorkload() processes = %v, want %v", processes, tt.wantProcesses)
+			}
+		})
+	}
+}
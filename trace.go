@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVerbosity = errors.New("invalid verbosity")
+
+// Trace verbosity levels.
+const (
+	traceOff = iota
+	// traceSwitches logs decisions that hand the CPU to another process.
+	traceSwitches
+	// traceAll also logs decisions that keep the running process.
+	traceAll
+)
+
+// allAlgorithms is the verbosity key applying to every algorithm not
+// given a level of its own.
+const allAlgorithms = "*"
+
+// parseVerbosity parses a --verbose spec: either one level for every
+// algorithm ("2") or per-algorithm levels ("rr=2,sjf"), where a bare name
+// means level 1.
+func parseVerbosity(spec string) (map[string]int, error) {
+	levels := make(map[string]int)
+	if spec == "" {
+		return levels, nil
+	}
+	if level, err := strconv.Atoi(spec); err == nil {
+		levels[allAlgorithms] = level
+		return levels, validateLevel(level)
+	}
+
+	for _, field := range strings.Split(spec, ",") {
+		name, value, hasLevel := strings.Cut(field, "=")
+		if _, ok := lookupScheduler(name); !ok {
+			return nil, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidVerbosity, name)
+		}
+		level := traceSwitches
+		if hasLevel {
+			var err error
+			if level, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidVerbosity, err)
+			}
+		}
+		if err := validateLevel(level); err != nil {
+			return nil, err
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
+
+func validateLevel(level int) error {
+	if level < traceOff || level > traceAll {
+		return fmt.Errorf("%w: level must be %d-%d, got %d", ErrInvalidVerbosity, traceOff, traceAll, level)
+	}
+	return nil
+}
+
+// verbosityFor returns the trace level configured for one algorithm.
+func verbosityFor(levels map[string]int, name string) int {
+	if level, ok := levels[name]; ok {
+		return level
+	}
+	return levels[allAlgorithms]
+}
+
+// tracer logs scheduling decisions, one line each.
+type tracer struct {
+	w     io.Writer
+	name  string
+	level int
+}
+
+func (t tracer) observe(d decision) {
+	if t.level < traceAll && d.Chosen == d.Previous {
+		return
+	}
+	running := "-"
+	if d.Previous != nil {
+		running = fmt.Sprint(d.Previous.ProcessID)
+	}
+	ready := make([]string, len(d.Ready))
+	for i, r := range d.Ready {
+		ready[i] = fmt.Sprint(r.ProcessID)
+	}
+	_, _ = fmt.Fprintf(t.w, "[%s] t=%d ready=[%s] running=%s chose=%d: %s\n",
+		t.name, d.Time, strings.Join(ready, ","), running, d.Chosen.ProcessID, d.Reason)
+}
+
+// chainObservers combines decision observers, skipping nil ones.
+func chainObservers(observers ...func(d decision)) func(d decision) {
+	active := make([]func(d decision), 0, len(observers))
+	for _, o := range observers {
+		if o != nil {
+			active = append(active, o)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return nil
+	case 1:
+		return active[0]
+	}
+	return func(d decision) {
+		for _, o := range active {
+			o(d)
+		}
+	}
+}
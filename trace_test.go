@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseVerbosity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]int
+		wantErr error
+	}{
+		{name: "off", spec: "", want: map[string]int{}},
+		{name: "every algorithm", spec: "2", want: map[string]int{allAlgorithms: 2}},
+		{name: "per algorithm", spec: "rr=2,sjf", want: map[string]int{"rr": 2, "sjf": 1}},
+		{name: "level out of range", spec: "3", wantErr: ErrInvalidVerbosity},
+		{name: "unknown algorithm", spec: "mlfq=1", wantErr: ErrInvalidVerbosity},
+		{name: "bad level", spec: "rr=x", wantErr: ErrInvalidVerbosity},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseVerbosity(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseVerbosity() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseVerbosity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tracer(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		level     int
+		wantLines int
+	}{
+		{name: "switches only", level: traceSwitches, wantLines: 4},
+		{name: "every decision", level: traceAll, wantLines: 5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			simulate(processes, newSJFPolicy(defaultRunQueue), simOptions{observer: tracer{w: &w, name: "sjf", level: tt.level}.observe})
+			if got := strings.Count(w.String(), "\n"); got != tt.wantLines {
+				t.Errorf("tracer wrote %d lines, want %d:\n%s", got, tt.wantLines, w.String())
+			}
+			if !strings.Contains(w.String(), "[sjf] t=6 ready=[3] running=2 chose=3: preempts 2") {
+				t.Errorf("tracer output = %v, missing the preemption at t=6", w.String())
+			}
+		})
+	}
+}
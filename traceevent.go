@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// traceEvent is one event of a Chrome trace-event JSON file, as written by
+// chrome://tracing and exported by Perfetto. Times are in microseconds.
+type traceEvent struct {
+	Name string  `json:"name"`
+	Ph   string  `json:"ph"`
+	TS   float64 `json:"ts"`
+	Dur  float64 `json:"dur"`
+	PID  int64   `json:"pid"`
+	TID  int64   `json:"tid"`
+	Args struct {
+		Name string `json:"name"`
+	} `json:"args"`
+}
+
+// parseTraceEvents reads a trace-event JSON file, either a bare array of
+// events or an object holding them under "traceEvents". Each thread that
+// has slices, complete (X) events or matching begin (B) and end (E)
+// events, becomes a process identified by its thread ID and named by its
+// thread_name metadata. Threads are told apart by process and thread ID;
+// when processes share a thread ID, the one with the lowest process ID
+// keeps it and the others get fresh ones after the largest thread ID.
+// It arrives when its first slice starts, and its
+// burst is the time covered by its slices, counting nested ones once and
+// at least one tick. Slices still open at the end of the trace are left
+// out.
+func parseTraceEvents(r io.Reader, tick time.Duration) ([]Process, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var events []traceEvent
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var doc struct {
+			TraceEvents []traceEvent `json:"traceEvents"`
+		}
+		err = json.Unmarshal(trimmed, &doc)
+		events = doc.TraceEvents
+	} else {
+		err = json.Unmarshal(trimmed, &events)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	type (
+		thread struct{ pid, tid int64 }
+		span   struct{ start, stop float64 }
+	)
+	var (
+		names  = make(map[thread]string)
+		spans  = make(map[thread][]span)
+		opened = make(map[thread][]float64)
+	)
+	for i, e := range events {
+		th := thread{e.PID, e.TID}
+		switch e.Ph {
+		case "M":
+			if e.Name == "thread_name" {
+				names[th] = e.Args.Name
+			}
+		case "X":
+			if e.Dur < 0 {
+				return nil, fmt.Errorf("event %d: negative duration %g", i+1, e.Dur)
+			}
+			spans[th] = append(spans[th], span{e.TS, e.TS + e.Dur})
+		case "B":
+			opened[th] = append(opened[th], e.TS)
+		case "E":
+			stack := opened[th]
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("event %d: thread %d of process %d ends a slice it never began", i+1, e.TID, e.PID)
+			}
+			spans[th] = append(spans[th], span{stack[len(stack)-1], e.TS})
+			opened[th] = stack[:len(stack)-1]
+		}
+	}
+
+	threads := make([]thread, 0, len(spans))
+	var next int64
+	for th := range spans {
+		threads = append(threads, th)
+		next = max(next, th.tid+1)
+	}
+	sort.Slice(threads, func(i, j int) bool {
+		if threads[i].tid != threads[j].tid {
+			return threads[i].tid < threads[j].tid
+		}
+		return threads[i].pid < threads[j].pid
+	})
+
+	micros := func(us float64) time.Duration { return time.Duration(math.Round(us * float64(time.Microsecond))) }
+	processes := make([]Process, 0, len(spans))
+	for k, th := range threads {
+		ss := spans[th]
+		sort.Slice(ss, func(i, j int) bool { return ss[i].start < ss[j].start })
+		var (
+			covered float64
+			end     = ss[0].start
+		)
+		for _, s := range ss {
+			if s.stop > end {
+				covered += s.stop - max(s.start, end)
+				end = s.stop
+			}
+		}
+		pid := th.tid
+		if k > 0 && threads[k-1].tid == th.tid {
+			pid, next = next, next+1
+		}
+		processes = append(processes, Process{
+			ProcessID:     pid,
+			Name:          names[th],
+			ArrivalTime:   roundTicks(micros(ss[0].start), tick),
+			BurstDuration: max(roundTicks(micros(covered), tick), 1),
+		})
+	}
+	return importedProcesses(processes), nil
+}
+
+// outputTraceEvents writes a run's results as trace-event JSON, to open in
+// Perfetto or chrome://tracing: each algorithm is a trace process with a
+// thread for the simulated CPU, and each Gantt slice a complete event on
+// it, named after the process that ran, trimmed to the --from/--to
+// window. A time unit lasts cfg.Tick, or a millisecond when that is
+// unset, as import assumes.
+func outputTraceEvents(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	opts := cfg.outputOptions()
+	unit := cfg.Tick
+	if unit <= 0 {
+		unit = time.Millisecond
+	}
+	micros := func(ticks int64) float64 {
+		return float64(ticks) * float64(unit) / float64(cfg.ticksPerUnit()) / float64(time.Microsecond)
+	}
+
+	events := make([]traceEvent, 0)
+	for i, row := range summary {
+		pid := int64(i + 1)
+		process := traceEvent{Name: "process_name", Ph: "M", PID: pid}
+		process.Args.Name = row.Scheduler.Title
+		thread := traceEvent{Name: "thread_name", Ph: "M", PID: pid}
+		thread.Args.Name = "CPU 0"
+		events = append(events, process, thread)
+
+		names := processNames(row.Result.Schedule)
+		for _, s := range clipGantt(row.Result.Gantt, opts.from, opts.to) {
+			if s.Idle {
+				// The gaps show idle time, and import reads no IDLE process.
+				continue
+			}
+			name := names[s.PID]
+			if name == "" {
+				name = "PID " + strconv.FormatInt(s.PID, 10)
+			}
+			events = append(events, traceEvent{Name: name, Ph: "X", TS: micros(s.Start), Dur: micros(s.Stop - s.Start), PID: pid})
+		}
+	}
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseTraceEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []Process
+		wantErr bool
+	}{
+		{
+			name: "object with nested and paired slices",
+			input: `{"traceEvents": [
+				{"name": "thread_name", "ph": "M", "pid": 1, "tid": 7, "args": {"name": "renderer"}},
+				{"name": "frame", "ph": "X", "ts": 1000, "dur": 4000, "pid": 1, "tid": 7},
+				{"name": "layout", "ph": "X", "ts": 2000, "dur": 1000, "pid": 1, "tid": 7},
+				{"name": "paint", "ph": "X", "ts": 9000, "dur": 2000, "pid": 1, "tid": 7},
+				{"name": "io", "ph": "B", "ts": 3000, "pid": 1, "tid": 9},
+				{"name": "io", "ph": "E", "ts": 3400, "pid": 1, "tid": 9},
+				{"name": "open", "ph": "B", "ts": 9000, "pid": 1, "tid": 11}
+			]}`,
+			want: []Process{
+				{ProcessID: 7, Name: "renderer", ArrivalTime: 0, BurstDuration: 6},
+				{ProcessID: 9, ArrivalTime: 2, BurstDuration: 1},
+			},
+		},
+		{
+			name:  "bare array",
+			input: `[{"name": "work", "ph": "X", "ts": 0, "dur": 3000, "pid": 1, "tid": 2}]`,
+			want:  []Process{{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3}},
+		},
+		{
+			name: "processes sharing a thread ID",
+			input: `[
+				{"name": "thread_name", "ph": "M", "pid": 2, "tid": 1, "args": {"name": "worker"}},
+				{"name": "thread_name", "ph": "M", "pid": 1, "tid": 1, "args": {"name": "main"}},
+				{"name": "work", "ph": "B", "ts": 0, "pid": 1, "tid": 1},
+				{"name": "work", "ph": "B", "ts": 1000, "pid": 2, "tid": 1},
+				{"name": "work", "ph": "E", "ts": 3000, "pid": 1, "tid": 1},
+				{"name": "work", "ph": "X", "ts": 2000, "dur": 2000, "pid": 1, "tid": 4},
+				{"name": "work", "ph": "E", "ts": 6000, "pid": 2, "tid": 1}
+			]`,
+			want: []Process{
+				{ProcessID: 1, Name: "main", ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: 5, Name: "worker", ArrivalTime: 1, BurstDuration: 5},
+				{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2},
+			},
+		},
+		{
+			name:    "end without begin",
+			input:   `[{"name": "io", "ph": "E", "ts": 3400, "pid": 1, "tid": 9}]`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			input:   "trace",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTraceEvents(strings.NewReader(tt.input), time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTraceEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTraceEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputTraceEvents(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, Name: "cc", BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 5},
+	}
+	summary := []summaryRow{{
+		Scheduler: Scheduler{Title: "First-come, first-serve"},
+		Result:    simulate(processes, &fcfsPolicy{}, simOptions{}),
+	}}
+	var w bytes.Buffer
+	if err := outputTraceEvents(&w, Config{Tick: 2 * time.Millisecond, Resolution: 2}, nil, summary); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"traceEvents":[` +
+		`{"name":"process_name","ph":"M","ts":0,"dur":0,"pid":1,"tid":0,"args":{"name":"First-come, first-serve"}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"dur":0,"pid":1,"tid":0,"args":{"name":"CPU 0"}},` +
+		`{"name":"cc","ph":"X","ts":0,"dur":3000,"pid":1,"tid":0,"args":{"name":""}},` +
+		`{"name":"PID 2","ph":"X","ts":5000,"dur":2000,"pid":1,"tid":0,"args":{"name":""}}` +
+		`],"displayTimeUnit":"ms"}` + "\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputTraceEvents() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Imported back, the CPU thread is one process busy for the slices.
+	got, err := parseTraceEvents(&w, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Process{{Name: "CPU 0", BurstDuration: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTraceEvents() = %v, want %v", got, want)
+	}
+
+	w.Reset()
+	if err := outputTraceEvents(&w, Config{Tick: 2 * time.Millisecond, Resolution: 2, From: 1, To: 6}, nil, summary); err != nil {
+		t.Fatal(err)
+	}
+	for _, slice := range []string{
+		`{"name":"cc","ph":"X","ts":1000,"dur":2000,`,
+		`{"name":"PID 2","ph":"X","ts":5000,"dur":1000,`,
+	} {
+		if !strings.Contains(w.String(), slice) {
+			t.Errorf("outputTraceEvents() in the window 1..6 =\n%s\nwant it to contain %s", w.String(), slice)
+		}
+	}
+}
@@ -0,0 +1,726 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidWorkload = errors.New("invalid workload")
+
+// workloadColumns names the CSV columns in positional file order.
+var workloadColumns = []string{"process ID", "burst duration", "arrival time", "priority", "deadline", "bursts", "name", "affinity", "nice", "owner", "dependencies", "weight", "period", "jitter"}
+
+// Indexes into workloadColumns of the fields that are not single integers,
+// of nice, the one integer that may be negative, of weight, which must be
+// positive, and of the period and jitter of periodic tasks.
+const (
+	burstsField   = 5
+	nameField     = 6
+	affinityField = 7
+	niceField     = 8
+	ownerField    = 9
+	dependsField  = 10
+	weightField   = 11
+	periodField   = 12
+	jitterField   = 13
+)
+
+// workloadHeaderNames maps the names accepted in a CSV header row onto
+// indexes into workloadColumns.
+var workloadHeaderNames = map[string]int{
+	"pid":        0,
+	"id":         0,
+	"burst":      1,
+	"arrival":    2,
+	"priority":   3,
+	"deadline":   4,
+	"bursts":     burstsField,
+	"name":       nameField,
+	"affinity":   affinityField,
+	"nice":       niceField,
+	"owner":      ownerField,
+	"depends_on": dependsField,
+	"weight":     weightField,
+	"tickets":    weightField,
+	"period":     periodField,
+	"jitter":     jitterField,
+}
+
+// workloadLayout maps each CSV column position onto an index into
+// workloadColumns.
+type workloadLayout []int
+
+// positionalLayout is the layout of a file without a header row: process
+// ID, burst, arrival and an optional priority and deadline. The other
+// columns can only be given under a header.
+var positionalLayout = workloadLayout{0, 1, 2, 3, 4}
+
+// isHeaderRow reports whether the first row of a workload names its
+// columns rather than holding a process. Headers start with a name where
+// a process would start with its integer ID.
+func isHeaderRow(row []string) bool {
+	if len(row) == 0 {
+		return false
+	}
+	_, err := strconv.ParseInt(strings.TrimSpace(row[0]), 10, 64)
+	return err != nil
+}
+
+// parseHeader builds the layout named by a header row such as
+// "arrival,pid,burst". Names are case-insensitive; pid, arrival and burst
+// or bursts are required, the others optional.
+func parseHeader(row []string) (workloadLayout, error) {
+	layout := make(workloadLayout, len(row))
+	seen := make(map[int]bool)
+	for i, name := range row {
+		field, ok := workloadHeaderNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q in header, want pid, name, owner, burst, arrival, priority, nice, weight, deadline, period, jitter, bursts, affinity or depends_on", name)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("column %q appears twice in header", name)
+		}
+		seen[field] = true
+		layout[i] = field
+	}
+	for field := 0; field < 3; field++ {
+		if !seen[field] && !(field == 1 && seen[burstsField]) {
+			return nil, fmt.Errorf("header has no %s column", workloadColumns[field])
+		}
+	}
+	return layout, nil
+}
+
+// process assembles a Process from a row's values, given in layout order.
+// Values beyond the layout, and in the columns that are not integers, are
+// ignored.
+func (l workloadLayout) process(values []int64) Process {
+	var fields [14]int64
+	for i, v := range values {
+		if i < len(l) {
+			fields[l[i]] = v
+		}
+	}
+	return Process{
+		ProcessID:     fields[0],
+		BurstDuration: fields[1],
+		ArrivalTime:   fields[2],
+		Priority:      fields[3],
+		Deadline:      fields[4],
+		Period:        fields[periodField],
+		Jitter:        fields[jitterField],
+	}
+}
+
+// namedFields holds a process's values given by name, as YAML and TOML
+// workloads hold them. Values are keyed by index into workloadColumns.
+type namedFields struct {
+	values   map[int]int64
+	bursts   []Burst
+	name     string
+	owner    string
+	affinity uint64
+	deps     []int64
+	// stamped is set when the arrival is a timestamp.
+	stamped bool
+}
+
+// arrivalStamped reports whether the arrival a process takes, its own or
+// else the default, is a timestamp.
+func (f namedFields) arrivalStamped(defaults namedFields) bool {
+	if _, ok := f.values[arrivalField]; ok {
+		return f.stamped
+	}
+	return defaults.stamped
+}
+
+// value returns a row's value of field, if the layout has a column for it.
+func (l workloadLayout) value(values []int64, field int) (int64, bool) {
+	for i, f := range l[:min(len(l), len(values))] {
+		if f == field {
+			return values[i], true
+		}
+	}
+	return 0, false
+}
+
+// withWeights applies the nice value and weight in a row's values to p,
+// if the layout has columns for them.
+func (l workloadLayout) withWeights(p Process, values []int64) (Process, error) {
+	var err error
+	if nice, ok := l.value(values, niceField); ok {
+		_, priorityGiven := l.value(values, 3)
+		if p, err = withNice(p, nice, priorityGiven); err != nil {
+			return p, err
+		}
+	}
+	if weight, ok := l.value(values, weightField); ok {
+		return withWeight(p, weight)
+	}
+	return p, nil
+}
+
+// fieldsByName sorts out a process's values given by name. Names are
+// those of a CSV header row; unknown or repeated ones, and values of the
+// wrong type, are reported.
+func fieldsByName(raw map[string]any, opts loadOptions) (namedFields, []string) {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		fields = namedFields{values: make(map[int]int64, len(raw))}
+		msgs   []string
+	)
+	for _, name := range names {
+		field, ok := workloadHeaderNames[strings.ToLower(name)]
+		if !ok {
+			msgs = append(msgs, fmt.Sprintf("unknown field %q, want pid, name, owner, burst, arrival, priority, nice, weight, deadline, period, jitter, bursts, affinity or depends_on", name))
+			continue
+		}
+		if field == burstsField {
+			s, ok := raw[name].(string)
+			if !ok {
+				msgs = append(msgs, fmt.Sprintf("bursts must be a string such as \"cpu:5,io:3,cpu:4\", got %#v", raw[name]))
+				continue
+			}
+			bursts, err := parseBursts(s, opts)
+			if err != nil {
+				msgs = append(msgs, err.Error())
+				continue
+			}
+			fields.bursts = bursts
+			continue
+		}
+		if field == nameField || field == ownerField {
+			s, ok := raw[name].(string)
+			if !ok {
+				msgs = append(msgs, fmt.Sprintf("%s must be a string, got %#v", workloadColumns[field], raw[name]))
+				continue
+			}
+			if field == nameField {
+				fields.name = strings.TrimSpace(s)
+			} else {
+				fields.owner = strings.TrimSpace(s)
+			}
+			continue
+		}
+		if field == affinityField {
+			// Unquoted, a hex mask would decode as a CPU number.
+			s, ok := raw[name].(string)
+			if !ok {
+				msgs = append(msgs, fmt.Sprintf("affinity must be a string such as \"0-3\" or \"0x5\", got %#v", raw[name]))
+				continue
+			}
+			affinity, err := parseAffinity(s)
+			if err != nil {
+				msgs = append(msgs, err.Error())
+				continue
+			}
+			fields.affinity = affinity
+			continue
+		}
+		if field == dependsField {
+			deps, err := dependencyValue(raw[name])
+			if err != nil {
+				msgs = append(msgs, err.Error())
+				continue
+			}
+			fields.deps = deps
+			continue
+		}
+		if _, dup := fields.values[field]; dup {
+			msgs = append(msgs, fmt.Sprintf("%s given twice", workloadColumns[field]))
+			continue
+		}
+		v, ok := integerValue(raw[name])
+		if s, isTime := timeValue(raw[name]); isTime && timeFields[field] {
+			var err error
+			if field == arrivalField {
+				v, fields.stamped, err = parseArrival(s, opts)
+			} else {
+				v, err = parseTicks(s, opts)
+			}
+			if err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s %v", workloadColumns[field], err))
+				continue
+			}
+			ok = true
+		}
+		if !ok {
+			msgs = append(msgs, fmt.Sprintf("%s must be an integer, got %#v", workloadColumns[field], raw[name]))
+			continue
+		}
+		fields.values[field] = v
+	}
+	return fields, msgs
+}
+
+// integerValue converts an integer decoded from YAML or TOML, whichever
+// type the decoder chose for it, to an int64.
+func integerValue(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint64:
+		return int64(n), n <= math.MaxInt64
+	}
+	return 0, false
+}
+
+// timeValue renders a time decoded from YAML or TOML, a number, a string
+// such as "150ms" or a TOML date-time, as the text parseArrival reads.
+func timeValue(v any) (string, bool) {
+	if n, ok := integerValue(v); ok {
+		return strconv.FormatInt(n, 10), true
+	}
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case string:
+		return v, true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	}
+	return "", false
+}
+
+// dependencyValue converts the PIDs a process depends on, decoded from
+// YAML or TOML as a list of integers, a single integer or a string that
+// parseDependencies reads.
+func dependencyValue(v any) ([]int64, error) {
+	switch v := v.(type) {
+	case string:
+		return parseDependencies(v)
+	case []any:
+		deps := make([]int64, len(v))
+		for i, item := range v {
+			pid, ok := integerValue(item)
+			if !ok || pid < 0 {
+				return nil, fmt.Errorf("dependency %#v is not a process ID", item)
+			}
+			deps[i] = pid
+		}
+		return deps, nil
+	}
+	if pid, ok := integerValue(v); ok && pid >= 0 {
+		return []int64{pid}, nil
+	}
+	return nil, fmt.Errorf("depends_on must list process IDs, such as [1, 2], got %#v", v)
+}
+
+// namedProcess assembles a Process from fields, taking any that are
+// missing from defaults. Process ID, arrival and burst or bursts are
+// required, and no value may be negative.
+func namedProcess(fields, defaults namedFields) (Process, []string) {
+	var (
+		values [5]int64
+		msgs   []string
+		bursts = fields.bursts
+	)
+	if bursts == nil {
+		bursts = defaults.bursts
+	}
+	for field := range values {
+		v, ok := fields.values[field]
+		if !ok {
+			v, ok = defaults.values[field]
+		}
+		switch {
+		case !ok && field < 3 && !(field == 1 && bursts != nil):
+			msgs = append(msgs, fmt.Sprintf("missing %s", workloadColumns[field]))
+		case v < 0:
+			msgs = append(msgs, fmt.Sprintf("%s must not be negative, got %d", workloadColumns[field], v))
+		}
+		values[field] = v
+	}
+	p, err := withBursts(positionalLayout.process(values[:]), bursts)
+	if err != nil {
+		msgs = append(msgs, err.Error())
+	}
+	// A process's own priority or nice outranks both defaults, and its
+	// own nice or weight the default weight.
+	nice, niceGiven := fields.values[niceField]
+	_, priorityGiven := fields.values[3]
+	weight, weightGiven := fields.values[weightField]
+	if !weightGiven && !niceGiven {
+		weight, weightGiven = defaults.values[weightField]
+	}
+	if !niceGiven && !priorityGiven {
+		nice, niceGiven = defaults.values[niceField]
+		_, priorityGiven = defaults.values[3]
+	}
+	if niceGiven {
+		if p, err = withNice(p, nice, priorityGiven); err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	if weightGiven {
+		if p, err = withWeight(p, weight); err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	period, ok := fields.values[periodField]
+	if !ok {
+		period = defaults.values[periodField]
+	}
+	jitter, ok := fields.values[jitterField]
+	if !ok {
+		jitter = defaults.values[jitterField]
+	}
+	if p, err = withPeriod(p, period, jitter); err != nil {
+		msgs = append(msgs, err.Error())
+	}
+	p.Name = fields.name
+	p.Owner = fields.owner
+	if p.Owner == "" {
+		p.Owner = defaults.owner
+	}
+	p.Affinity = fields.affinity
+	p.DependsOn = fields.deps
+	if err := checkAffinity(p.Affinity); err != nil {
+		msgs = append(msgs, err.Error())
+	}
+	return p, msgs
+}
+
+// workloadProblem is one defect found in a workload file. Column is the
+// 1-based field index, or zero when the problem concerns the whole line.
+// Line is zero when the format gives no line, and Msg then says where.
+type workloadProblem struct {
+	Line   int
+	Column int
+	Msg    string
+	// Err is the error reading the workload failed with, if it did.
+	Err error
+}
+
+func (p workloadProblem) String() string {
+	if p.Line == 0 {
+		return p.Msg
+	}
+	if p.Column == 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Msg)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", p.Line, p.Column, p.Msg)
+}
+
+// workloadError rejects the workload named name, or an unnamed one when
+// name is empty, for problems, wrapping the read error among them if any.
+func workloadError(name string, problems []workloadProblem) error {
+	if name != "" {
+		name += ": "
+	}
+	for _, p := range problems {
+		if p.Err != nil {
+			return fmt.Errorf("%w: %s%w", ErrInvalidWorkload, name, p.Err)
+		}
+	}
+	return fmt.Errorf("%w: %s%s", ErrInvalidWorkload, name, joinProblems(problems))
+}
+
+// joinProblems lists problems on one line, for an error.
+func joinProblems(problems []workloadProblem) string {
+	msgs := make([]string, len(problems))
+	for i, p := range problems {
+		msgs[i] = p.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// parseWorkload reads a workload CSV, collecting every problem instead of
+// stopping at the first. Processes holds the rows that parsed cleanly.
+// Times given as durations are converted with ticks of length opts.tick.
+func parseWorkload(r io.Reader, opts loadOptions) ([]Process, []workloadProblem) {
+	var (
+		cr       = csv.NewReader(r)
+		pids     = newPIDSet(opts.onDuplicate)
+		stamps   arrivalStamps
+		problems = make([]workloadProblem, 0)
+		layout   workloadLayout
+		first    = true
+	)
+	cr.FieldsPerRecord = -1
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			problems = append(problems, workloadProblem{Line: parseErr.Line, Msg: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			problems = append(problems, workloadProblem{Msg: err.Error(), Err: err})
+			break
+		}
+
+		line, _ := cr.FieldPos(0)
+		if first {
+			first = false
+			if isHeaderRow(row) {
+				var err error
+				if layout, err = parseHeader(row); err != nil {
+					problems = append(problems, workloadProblem{Line: line, Msg: err.Error()})
+					break
+				}
+				continue
+			}
+		}
+		switch {
+		case layout != nil && len(row) != len(layout):
+			problems = append(problems, workloadProblem{
+				Line: line,
+				Msg:  fmt.Sprintf("expected %d columns as in the header, got %d", len(layout), len(row)),
+			})
+			continue
+		case layout == nil && (len(row) < 3 || len(row) > len(positionalLayout)):
+			problems = append(problems, workloadProblem{
+				Line: line,
+				Msg:  fmt.Sprintf("expected 3 to %d columns, got %d", len(positionalLayout), len(row)),
+			})
+			continue
+		}
+		columns := layout
+		if columns == nil {
+			columns = positionalLayout
+		}
+
+		var (
+			values   = make([]int64, len(row))
+			bursts   []Burst
+			name     string
+			owner    string
+			affinity uint64
+			deps     []int64
+			stamped  bool
+			arrival  int
+			bad      bool
+		)
+		for i := range row {
+			if columns[i] == nameField {
+				name = strings.TrimSpace(row[i])
+				continue
+			}
+			if columns[i] == ownerField {
+				owner = strings.TrimSpace(row[i])
+				continue
+			}
+			if columns[i] == dependsField {
+				var err error
+				if deps, err = parseDependencies(row[i]); err != nil {
+					problems = append(problems, workloadProblem{Line: line, Column: i + 1, Msg: err.Error()})
+					bad = true
+				}
+				continue
+			}
+			if columns[i] == affinityField {
+				var err error
+				if affinity, err = parseAffinity(row[i]); err == nil {
+					err = checkAffinity(affinity)
+				}
+				if err != nil {
+					problems = append(problems, workloadProblem{Line: line, Column: i + 1, Msg: err.Error()})
+					bad = true
+				}
+				continue
+			}
+			if columns[i] == burstsField {
+				var err error
+				if bursts, err = parseBursts(row[i], opts); err != nil {
+					problems = append(problems, workloadProblem{Line: line, Column: i + 1, Msg: err.Error()})
+					bad = true
+				}
+				continue
+			}
+			var v int64
+			if columns[i] == arrivalField {
+				v, stamped, err = parseArrival(row[i], opts)
+				arrival = i + 1
+			} else if timeFields[columns[i]] {
+				v, err = parseTicks(row[i], opts)
+			} else if v, err = strconv.ParseInt(row[i], 10, 64); err != nil {
+				err = fmt.Errorf("must be an integer, got %q", row[i])
+			}
+			switch {
+			case err != nil:
+				problems = append(problems, workloadProblem{
+					Line:   line,
+					Column: i + 1,
+					Msg:    fmt.Sprintf("%s %v", workloadColumns[columns[i]], err),
+				})
+				bad = true
+			case v < 0 && columns[i] != niceField && columns[i] != weightField:
+				problems = append(problems, workloadProblem{
+					Line:   line,
+					Column: i + 1,
+					Msg:    fmt.Sprintf("%s must not be negative, got %d", workloadColumns[columns[i]], v),
+				})
+				bad = true
+			}
+			values[i] = v
+		}
+		if bad {
+			continue
+		}
+
+		p, err := withBursts(columns.process(values), bursts)
+		if err == nil {
+			p, err = columns.withWeights(p, values)
+		}
+		if err == nil {
+			p, err = withPeriod(p, p.Period, p.Jitter)
+		}
+		if err != nil {
+			problems = append(problems, workloadProblem{Line: line, Msg: err.Error()})
+			continue
+		}
+		p.Name = name
+		p.Owner = owner
+		p.Affinity = affinity
+		p.DependsOn = deps
+		if err := stamps.add(stamped); err != nil {
+			problems = append(problems, workloadProblem{Line: line, Column: arrival, Msg: err.Error()})
+			continue
+		}
+		firstLine, err := pids.add(p, line)
+		if err != nil {
+			problems = append(problems, workloadProblem{Line: line, Msg: err.Error()})
+		}
+		if firstLine > 0 {
+			pidColumn := 1
+			for i, field := range columns[:len(row)] {
+				if field == 0 {
+					pidColumn = i + 1
+				}
+			}
+			problems = append(problems, workloadProblem{
+				Line:   line,
+				Column: pidColumn,
+				Msg:    fmt.Sprintf("duplicate process ID %d, first defined on line %d", p.ProcessID, firstLine),
+			})
+		}
+	}
+
+	return settleWorkload(pids, &stamps, problems, opts.seed)
+}
+
+// settleWorkload finishes reading a workload once every process has been
+// read: repeated PIDs are renumbered, timestamped arrivals made relative
+// and periodic tasks expanded into their jobs, jittered by seed.
+func settleWorkload(pids *pidSet, stamps *arrivalStamps, problems []workloadProblem, seed int64) ([]Process, []workloadProblem) {
+	processes := pids.workload()
+	stamps.normalize(processes)
+	processes, err := expandPeriodic(processes, seed)
+	if err != nil {
+		problems = append(problems, workloadProblem{Msg: err.Error()})
+	}
+	return processes, problems
+}
+
+// workloadFormats maps workload file extensions onto their parsers. Any
+// other extension is read as CSV.
+var workloadFormats = map[string]func(r io.Reader, opts loadOptions) ([]Process, []workloadProblem){
+	".yaml": parseYAMLWorkload,
+	".yml":  parseYAMLWorkload,
+	".json": parseYAMLWorkload,
+	".toml": parseTOMLWorkload,
+}
+
+// parseWorkloadFile parses a workload in the format its name's extension
+// selects, decompressing it first if it is compressed.
+func parseWorkloadFile(name string, r io.Reader, opts loadOptions) ([]Process, []workloadProblem) {
+	name, rc, err := decompress(name, r)
+	if err != nil {
+		return nil, []workloadProblem{{Msg: err.Error()}}
+	}
+	defer rc.Close()
+	return parseWorkloadAs(name, rc, opts)
+}
+
+// parseWorkloadAs reads a workload in the format its name's extension
+// selects, CSV by default, and checks it as a whole.
+func parseWorkloadAs(name string, r io.Reader, opts loadOptions) ([]Process, []workloadProblem) {
+	parse, ok := workloadFormats[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		parse = parseWorkload
+	}
+	processes, problems := parse(r, opts)
+	return processes, wholeWorkloadProblems(processes, problems)
+}
+
+// wholeWorkloadProblems adds to the problems found reading a workload those
+// that only show once every process has been read: there being none, and
+// dependencies that do not resolve. They are only looked for when every
+// process read cleanly.
+func wholeWorkloadProblems(processes []Process, problems []workloadProblem) []workloadProblem {
+	if len(problems) > 0 {
+		return problems
+	}
+	if len(processes) == 0 {
+		return append(problems, workloadProblem{Msg: "no processes"})
+	}
+	for _, msg := range dependencyProblems(processes) {
+		problems = append(problems, workloadProblem{Msg: msg})
+	}
+	return problems
+}
+
+// loadWorkload reads a workload for simulation in the format its name's
+// extension selects, decompressing it first if it is compressed, and fails
+// with every problem it has.
+func loadWorkload(name string, r io.Reader, opts loadOptions) ([]Process, error) {
+	name, rc, err := decompress(name, r)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	processes, problems := parseWorkloadAs(name, rc, opts)
+	if len(problems) > 0 {
+		return nil, workloadError(name, problems)
+	}
+	return processes, nil
+}
+
+// validate checks a workload file without running any simulation. Every
+// problem is printed with its line number; any problem fails the command.
+// Warnings are printed too, but do not.
+func validate(w io.Writer, args ...string) error {
+	cfg, args, err := parseArgs(append([]string{"validate"}, args...)...)
+	if err != nil {
+		return err
+	}
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return err
+	}
+	defer closeFile()
+
+	processes, problems := parseWorkloadFile(f.Name(), f, cfg.loadOptions())
+	for _, p := range problems {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", f.Name(), p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %d problem(s)", ErrInvalidWorkload, f.Name(), len(problems))
+	}
+
+	for _, warn := range workloadWarnings(processes) {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", f.Name(), warn)
+	}
+	_, _ = fmt.Fprintf(w, "%s: OK, %d processes\n", f.Name(), len(processes))
+	return nil
+}
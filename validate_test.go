@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		input        string
+		want         []Process
+		wantProblems []workloadProblem
+	}{
+		{
+			name:  "valid",
+			input: "1,5,0,2\n2,9,3\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+			},
+			wantProblems: []workloadProblem{},
+		},
+		{
+			name:  "every problem is reported",
+			input: "1,5,0,2\n2,x,3\n1,4,2,1\n3,-6,3\n4,1\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 2, Column: 2, Msg: `burst duration must be an integer or a duration such as 150ms, got "x"`},
+				{Line: 3, Column: 1, Msg: "duplicate process ID 1, first defined on line 1"},
+				{Line: 4, Column: 2, Msg: "burst duration must not be negative, got -6"},
+				{Line: 5, Msg: "expected 3 to 5 columns, got 2"},
+			},
+		},
+		{
+			name:  "header maps columns by name",
+			input: "priority,arrival,burst,pid\n2,0,5,1\n1,3,x,2\n1,4,2,1\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Column: 3, Msg: `burst duration must be an integer or a duration such as 150ms, got "x"`},
+				{Line: 4, Column: 4, Msg: "duplicate process ID 1, first defined on line 2"},
+			},
+		},
+		{
+			name:  "bursts stand in for the burst column",
+			input: "pid,arrival,bursts\n1,0,\"cpu:5,io:3,cpu:4\"\n2,1,\"cpu:5,io:3\"\n3,2,cpu:5,io:3\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 9, Bursts: []Burst{{Duration: 5}, {IO: true, Duration: 3}, {Duration: 4}}},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Column: 3, Msg: `bursts must start and end on the cpu, got "cpu:5,io:3"`},
+				{Line: 4, Msg: "expected 3 columns as in the header, got 4"},
+			},
+		},
+		{
+			name:  "names",
+			input: "pid,name,burst,arrival\n1, compiler ,5,0\n2,,9,3\n",
+			want: []Process{
+				{ProcessID: 1, Name: "compiler", BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+			},
+			wantProblems: []workloadProblem{},
+		},
+		{
+			name:  "owners",
+			input: "pid,owner,burst,arrival\n1,alice,5,0\n2,,9,3\n",
+			want: []Process{
+				{ProcessID: 1, Owner: "alice", BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+			},
+			wantProblems: []workloadProblem{},
+		},
+		{
+			name:  "dependencies",
+			input: "pid,burst,arrival,depends_on\n1,5,0,\n2,9,3,\"1\"\n3,1,4,1 x\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3, DependsOn: []int64{1}},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 4, Column: 4, Msg: `dependency "x" is not a process ID`},
+			},
+		},
+		{
+			name:  "affinity",
+			input: "pid,burst,arrival,affinity\n1,5,0,\"0,2\"\n2,9,3,0x2\n3,1,4,\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Affinity: 0b101},
+				{ProcessID: 3, BurstDuration: 1, ArrivalTime: 4},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Column: 4, Msg: "affinity 1 allows none of the 1 simulated CPU(s)"},
+			},
+		},
+		{
+			name:  "nice values map to priorities",
+			input: "pid,burst,arrival,nice\n1,5,0,-5\n2,9,3,20\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 15, Nice: -5, Weight: 3121},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Msg: "nice must be from -20 to 19, got 20"},
+			},
+		},
+		{
+			name:  "tickets",
+			input: "pid,burst,arrival,tickets\n1,5,0,100\n2,9,3,0\n3,1,4,-2\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Weight: 100},
+			},
+			wantProblems: []workloadProblem{
+				{Line: 3, Msg: "weight must be positive, got 0"},
+				{Line: 4, Msg: "weight must be positive, got -2"},
+			},
+		},
+		{
+			name:  "header without a required column",
+			input: "pid,burst,priority\n1,5,2\n",
+			want:  []Process{},
+			wantProblems: []workloadProblem{
+				{Line: 1, Msg: "header has no arrival time column"},
+			},
+		},
+		{
+			name:  "malformed CSV",
+			input: "1,\"5,0\n",
+			want:  []Process{},
+			wantProblems: []workloadProblem{
+				{Line: 1, Msg: `extraneous or missing " in quoted-field`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotProblems := parseWorkload(strings.NewReader(tt.input), loadOptions{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWorkload() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotProblems, tt.wantProblems) {
+				t.Errorf("parseWorkload() problems = %v, want %v", gotProblems, tt.wantProblems)
+			}
+		})
+	}
+}
+
+func Test_loadWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		input   string
+		wantMsg string
+	}{
+		{name: "valid", file: "w.csv", input: "1,5,0,2\n"},
+		{name: "empty CSV", file: "w.csv", wantMsg: "invalid workload: w.csv: no processes"},
+		{name: "header only", file: "w.csv", input: "pid,burst,arrival\n", wantMsg: "invalid workload: w.csv: no processes"},
+		{name: "empty YAML", file: "w.yaml", input: "processes: []\n", wantMsg: "invalid workload: w.yaml: no processes"},
+		{name: "bad CSV cell", file: "w.csv", input: "1,x,0\n", wantMsg: "invalid workload: w.csv: line 1, column 2: "},
+		{name: "unknown dependency", file: "w.csv", input: "pid,burst,arrival,depends_on\n1,5,0,2\n", wantMsg: "invalid workload: w.csv: process 1 depends on unknown process 2"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := loadWorkload(tt.file, strings.NewReader(tt.input), loadOptions{})
+			if tt.wantMsg == "" {
+				if err != nil {
+					t.Errorf("loadWorkload() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidWorkload) || !strings.HasPrefix(err.Error(), tt.wantMsg) {
+				t.Errorf("loadWorkload() error = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
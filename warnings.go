@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// warningCode identifies a kind of warning. Codes never change meaning,
+// so scripts can match on them.
+type warningCode string
+
+const (
+	// warnUnsorted: the workload is not in arrival order.
+	warnUnsorted warningCode = "W001"
+	// warnZeroBurst: a process needs no CPU time at all.
+	warnZeroBurst warningCode = "W002"
+	// warnNoAging: priority scheduling, which has no aging, can starve
+	// processes of lower priority.
+	warnNoAging warningCode = "W003"
+)
+
+// warning is a soft problem: the run goes ahead, but its results may not
+// be what was meant.
+type warning struct {
+	Code warningCode `json:"code"`
+	Msg  string      `json:"message"`
+}
+
+func (w warning) String() string {
+	return fmt.Sprintf("warning %s: %s", w.Code, w.Msg)
+}
+
+// workloadWarnings lists the soft problems of a workload on its own.
+func workloadWarnings(processes []Process) []warning {
+	var warnings []warning
+	for i := 1; i < len(processes); i++ {
+		if prev, p := processes[i-1], processes[i]; p.ArrivalTime < prev.ArrivalTime {
+			warnings = append(warnings, warning{warnUnsorted, fmt.Sprintf(
+				"processes are not in arrival order: process %d arriving at %d is listed after process %d arriving at %d",
+				p.ProcessID, p.ArrivalTime, prev.ProcessID, prev.ArrivalTime)})
+			break
+		}
+	}
+	for _, p := range processes {
+		if p.BurstDuration == 0 {
+			warnings = append(warnings, warning{warnZeroBurst, fmt.Sprintf(
+				"process %d has no CPU time, so it completes on arrival without running", p.ProcessID)})
+		}
+	}
+	return warnings
+}
+
+// runWarnings lists the soft problems of running processes under cfg.
+// Starvation is only warned of when priority scheduling was asked for,
+// not when it merely runs among the default algorithms.
+func runWarnings(cfg Config, processes []Process) []warning {
+	warnings := workloadWarnings(processes)
+	if slices.Contains(cfg.Algorithms, "priority") && !slices.Equal(cfg.Algorithms, defaultConfig().Algorithms) {
+		for _, p := range processes {
+			if p.Priority != processes[0].Priority {
+				warnings = append(warnings, warning{warnNoAging,
+					"priority scheduling has no aging, so processes of lower priority can starve"})
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// outputWarnings writes one warning per line.
+func outputWarnings(w io.Writer, warnings []warning) {
+	for _, warn := range warnings {
+		_, _ = fmt.Fprintln(w, warn)
+	}
+}
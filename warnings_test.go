@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_runWarnings(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		algorithms []string
+		processes  []Process
+		want       []warningCode
+	}{
+		{
+			name:       "none",
+			algorithms: []string{"fcfs", "priority"},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 5, Priority: 1}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1}},
+		},
+		{
+			name:       "unsorted, reported once",
+			algorithms: []string{"fcfs"},
+			processes:  []Process{{ProcessID: 1, ArrivalTime: 4, BurstDuration: 1}, {ProcessID: 2, BurstDuration: 1}, {ProcessID: 3, BurstDuration: 1}, {ProcessID: 4, BurstDuration: 1, ArrivalTime: 2}, {ProcessID: 5, BurstDuration: 1}},
+			want:       []warningCode{warnUnsorted},
+		},
+		{
+			name:       "zero bursts",
+			algorithms: []string{"fcfs"},
+			processes:  []Process{{ProcessID: 1}, {ProcessID: 2, BurstDuration: 3}, {ProcessID: 3, ArrivalTime: 1}},
+			want:       []warningCode{warnZeroBurst, warnZeroBurst},
+		},
+		{
+			name:       "priority without aging",
+			algorithms: []string{"priority"},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 5, Priority: 1}, {ProcessID: 2, BurstDuration: 9, Priority: 2}},
+			want:       []warningCode{warnNoAging},
+		},
+		{
+			name:       "priority by default",
+			algorithms: defaultConfig().Algorithms,
+			processes:  []Process{{ProcessID: 1, BurstDuration: 5, Priority: 1}, {ProcessID: 2, BurstDuration: 9, Priority: 2}},
+		},
+		{
+			name:       "priorities unused",
+			algorithms: []string{"fcfs", "rr"},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 5, Priority: 1}, {ProcessID: 2, BurstDuration: 9, Priority: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []warningCode
+			for _, w := range runWarnings(Config{Algorithms: tt.algorithms}, tt.processes) {
+				got = append(got, w.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runWarnings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// watchInterval is how often --watch polls the workload for changes.
+const watchInterval = 500 * time.Millisecond
+
+// watch reruns the simulation every time the workload file's modification
+// time changes, until ctx is done. A workload that fails to parse, say
+// half-way through an edit, is reported and the file watched again rather
+// than ending the session.
+func watch(ctx context.Context, w io.Writer, cfg Config, interval time.Duration, args ...string) error {
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		return err
+	}
+	path := f.Name()
+	closeFile()
+
+	var lastMod time.Time
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(w, "%v: waiting for %s\n", err, path)
+		case !info.ModTime().Equal(lastMod):
+			lastMod = info.ModTime()
+			_, _ = fmt.Fprintf(w, "=== %s changed at %s ===\n", path, lastMod.Format(time.TimeOnly))
+			if err := rerun(w, cfg, path); err != nil {
+				_, _ = fmt.Fprintln(w, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func rerun(w io.Writer, cfg Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer f.Close()
+
+	processes, problems := parseWorkloadFile(path, f, cfg.loadOptions())
+	for _, p := range problems {
+		_, _ = fmt.Fprintf(w, "%s: %s\n", path, p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s: %d problem(s), waiting for the next change", ErrInvalidWorkload, path, len(problems))
+	}
+	return run(w, cfg, processes)
+}
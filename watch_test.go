@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer lets the test read output while watch is still writing it.
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func Test_watch(t *testing.T) {
+	t.Parallel()
+	workload := path.Join(t.TempDir(), "workload.csv")
+	if err := os.WriteFile(workload, []byte("1,5,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs"}
+
+	var w syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- watch(ctx, &w, cfg, time.Millisecond, "binary_name", workload) }()
+
+	waitFor := func(want string, count int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for strings.Count(w.String(), want) < count {
+			if time.Now().After(deadline) {
+				t.Fatalf("watch() output = %v, want %d x %q", w.String(), count, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor("First-come, first-serve", 1)
+
+	// A broken edit is reported without ending the session.
+	later := time.Now().Add(time.Second)
+	if err := os.WriteFile(workload, []byte("1,x,0,2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(workload, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("burst duration must be an integer", 1)
+
+	later = later.Add(time.Second)
+	if err := os.WriteFile(workload, []byte("1,5,0,2\n2,3,1,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(workload, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("First-come, first-serve", 2)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watch() error = %v", err)
+	}
+}
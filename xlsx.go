@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxFile is the workbook --format xlsx writes in the output directory.
+const xlsxFile = "results.xlsx"
+
+// outputXLSX writes a run's results as an Excel workbook for submitting
+// lab work: a Summary sheet with the metrics of every algorithm, then a
+// sheet per algorithm, named after it, holding its schedule table. The
+// tables are those outputCSV writes, with numbers stored as numbers. The
+// workbook goes in cfg.OutputDir, and w notes it.
+func outputXLSX(w io.Writer, cfg Config, _ []warning, summary []summaryRow) error {
+	dir := cfg.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%v: error creating output directory", err)
+	}
+	opts := cfg.outputOptions()
+
+	f := excelize.NewFile()
+	defer f.Close()
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	if err := f.SetSheetName("Sheet1", "Summary"); err != nil {
+		return err
+	}
+	if err := writeXLSXSheet(f, "Summary", metricsRecords(summary, metricPlugins, opts.numbers), bold); err != nil {
+		return err
+	}
+	for _, row := range summary {
+		if _, err := f.NewSheet(row.Scheduler.Name); err != nil {
+			return err
+		}
+		if err := writeXLSXSheet(f, row.Scheduler.Name, scheduleRecords(row, opts), bold); err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(dir, xlsxFile)
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("%v: error writing %s", err, path)
+	}
+	_, _ = fmt.Fprintf(w, "wrote %s\n", path)
+	return nil
+}
+
+// writeXLSXSheet fills sheet with records, the header row in bold. Cells
+// that parse as numbers are stored as numbers, and empty ones are left
+// blank.
+func writeXLSXSheet(f *excelize.File, sheet string, records [][]string, header int) error {
+	for r, record := range records {
+		values := make([]any, len(record))
+		for c, cell := range record {
+			if v, err := strconv.ParseFloat(cell, 64); err == nil && r > 0 {
+				values[c] = v
+			} else if cell != "" {
+				values[c] = cell
+			}
+		}
+		if err := f.SetSheetRow(sheet, "A"+strconv.Itoa(r+1), &values); err != nil {
+			return err
+		}
+	}
+	return f.SetRowStyle(sheet, 1, 1, header)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_outputXLSX(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1},
+	}
+	cfg := defaultConfig()
+	cfg.Algorithms = []string{"fcfs", "rr"}
+	cfg.Format = "xlsx"
+	cfg.OutputDir = t.TempDir()
+	var w bytes.Buffer
+	if err := run(&w, cfg, processes); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(cfg.OutputDir, xlsxFile)
+	if got, want := w.String(), "wrote "+path+"\n"; got != want {
+		t.Errorf("run() = %q, want %q", got, want)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got, want := f.GetSheetList(), []string{"Summary", "fcfs", "rr"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sheets = %v, want %v", got, want)
+	}
+	rows, err := f.GetRows("fcfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"},
+		{"1", "0", "3", "0", "0", "3", "3"},
+		{"2", "0", "1", "1", "2", "3", "4"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("fcfs sheet = %v, want %v", rows, want)
+	}
+	if typ, err := f.GetCellType("Summary", "B2"); err != nil || typ == excelize.CellTypeSharedString || typ == excelize.CellTypeInlineString {
+		t.Errorf("Summary!B2 type = %v, %v, want a number", typ, err)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlWorkload is the layout of a YAML workload file:
+//
+//	defaults: &base
+//	  priority: 10
+//	processes:
+//	  - {pid: 1, burst: 5, arrival: 0}
+//	  - {pid: 2, burst: 9, arrival: 3, priority: 1}
+//	  - <<: *base
+//	    pid: 3
+//	    bursts: cpu:2,io:5,cpu:4
+//	    arrival: 6
+//
+// Each process takes the same field names as a CSV header row. Fields it
+// leaves out are taken from defaults, and anchors and merge keys can share
+// further values between processes. The file is a scenario, so it can hold
+// settings as well, which loadScenario reads.
+type yamlWorkload struct {
+	scenarioSettings `yaml:",inline"`
+	Defaults         yaml.Node   `yaml:"defaults"`
+	Processes        []yaml.Node `yaml:"processes"`
+}
+
+// parseYAMLWorkload reads a YAML workload, collecting every problem, with
+// the line of the process it concerns, instead of stopping at the first.
+func parseYAMLWorkload(r io.Reader, opts loadOptions) ([]Process, []workloadProblem) {
+	var doc yamlWorkload
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, yamlProblems(err)
+	}
+
+	var (
+		pids   = newPIDSet(opts.onDuplicate)
+		stamps arrivalStamps
+	)
+	defaults, problems := yamlFields(&doc.Defaults, make([]workloadProblem, 0), opts)
+	for i := range doc.Processes {
+		node := &doc.Processes[i]
+		fields, more := yamlFields(node, nil, opts)
+		if len(more) > 0 {
+			problems = append(problems, more...)
+			continue
+		}
+		p, msgs := namedProcess(fields, defaults)
+		if len(msgs) == 0 {
+			if err := stamps.add(fields.arrivalStamped(defaults)); err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		for _, msg := range msgs {
+			problems = append(problems, workloadProblem{Line: node.Line, Msg: msg})
+		}
+		if len(msgs) > 0 {
+			continue
+		}
+		firstLine, err := pids.add(p, node.Line)
+		if err != nil {
+			problems = append(problems, workloadProblem{Line: node.Line, Msg: err.Error()})
+		}
+		if firstLine > 0 {
+			problems = append(problems, workloadProblem{
+				Line: node.Line,
+				Msg:  fmt.Sprintf("duplicate process ID %d, first defined on line %d", p.ProcessID, firstLine),
+			})
+		}
+	}
+
+	return settleWorkload(pids, &stamps, problems, opts.seed)
+}
+
+// yamlFields decodes a process's named values, appending a problem for
+// every unknown or repeated name. An absent node has no fields.
+func yamlFields(node *yaml.Node, problems []workloadProblem, opts loadOptions) (namedFields, []workloadProblem) {
+	if node.Kind == 0 {
+		return namedFields{}, problems
+	}
+	var raw map[string]any
+	if err := node.Decode(&raw); err != nil {
+		return namedFields{}, append(problems, yamlProblems(err)...)
+	}
+	fields, msgs := fieldsByName(raw, opts)
+	for _, msg := range msgs {
+		problems = append(problems, workloadProblem{Line: node.Line, Msg: msg})
+	}
+	return fields, problems
+}
+
+// yamlProblems turns a YAML decoding error into problems, recovering the
+// line numbers the decoder reports as a "line N: " prefix.
+func yamlProblems(err error) []workloadProblem {
+	msgs := []string{strings.TrimPrefix(err.Error(), "yaml: ")}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	}
+	problems := make([]workloadProblem, len(msgs))
+	for i, msg := range msgs {
+		var line int
+		if _, err := fmt.Sscanf(msg, "line %d:", &line); err == nil {
+			msg = strings.TrimSpace(msg[strings.Index(msg, ":")+1:])
+		}
+		problems[i] = workloadProblem{Line: line, Msg: msg}
+	}
+	return problems
+}
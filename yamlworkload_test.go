@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseYAMLWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         string
+		wantProcesses []Process
+		wantProblems  []string
+	}{
+		{
+			name: "defaults and merge keys",
+			input: `defaults:
+  priority: 7
+processes:
+  - &base {pid: 1, burst: 5, arrival: 0}
+  - {<<: *base, pid: 2, priority: 1}
+  - {id: 3, burst: 2, arrival: 4}
+`,
+			wantProcesses: []Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 7},
+				{ProcessID: 2, BurstDuration: 5, ArrivalTime: 0, Priority: 1},
+				{ProcessID: 3, BurstDuration: 2, ArrivalTime: 4, Priority: 7},
+			},
+			wantProblems: []string{},
+		},
+		{
+			name: "every problem reported with its line",
+			input: `processes:
+  - {pid: 1, burst: 5}
+  - {pid: 2, burst: -1, arrival: 0}
+  - {pid: 3, burst: 1, arrival: 0, colour: 9}
+  - {pid: 4, burst: x, arrival: 0}
+  - {pid: 5, burst: 1, arrival: 0}
+  - {pid: 5, burst: 1, arrival: 2}
+`,
+			wantProcesses: []Process{{ProcessID: 5, BurstDuration: 1}},
+			wantProblems: []string{
+				"line 2: missing arrival time",
+				"line 3: burst duration must not be negative, got -1",
+				`line 4: unknown field "colour", want pid, name, owner, burst, arrival, priority, nice, weight, deadline, period, jitter, bursts, affinity or depends_on`,
+				`line 5: burst duration must be an integer or a duration such as 150ms, got "x"`,
+				"line 7: duplicate process ID 5, first defined on line 6",
+			},
+		},
+		{
+			name: "bursts",
+			input: `processes:
+  - pid: 1
+    arrival: 0
+    bursts: cpu:5,io:3,cpu:4
+  - {pid: 2, arrival: 0, bursts: 7}
+`,
+			wantProcesses: []Process{
+				{ProcessID: 1, BurstDuration: 9, Bursts: []Burst{{Duration: 5}, {IO: true, Duration: 3}, {Duration: 4}}},
+			},
+			wantProblems: []string{`line 5: bursts must be a string such as "cpu:5,io:3,cpu:4", got 7`},
+		},
+		{
+			name: "owners default",
+			input: `defaults: {owner: alice}
+processes:
+  - {pid: 1, burst: 2, arrival: 0}
+  - {pid: 2, burst: 3, arrival: 1, owner: bob}
+  - {pid: 3, burst: 4, arrival: 2, owner: 7}
+`,
+			wantProcesses: []Process{
+				{ProcessID: 1, BurstDuration: 2, Owner: "alice"},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1, Owner: "bob"},
+			},
+			wantProblems: []string{"line 5: owner must be a string, got 7"},
+		},
+		{
+			name: "dependencies",
+			input: `processes:
+  - {pid: 1, burst: 2, arrival: 0}
+  - {pid: 2, burst: 3, arrival: 0, depends_on: [1]}
+  - {pid: 3, burst: 4, arrival: 0, depends_on: 1}
+  - {pid: 4, burst: 4, arrival: 0, depends_on: [a]}
+`,
+			wantProcesses: []Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, BurstDuration: 3, DependsOn: []int64{1}},
+				{ProcessID: 3, BurstDuration: 4, DependsOn: []int64{1}},
+			},
+			wantProblems: []string{`line 5: dependency "a" is not a process ID`},
+		},
+		{
+			name:          "unknown top-level key",
+			input:         "jobs: []\n",
+			wantProcesses: nil,
+			wantProblems:  []string{"line 1: field jobs not found in type main.yamlWorkload"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, problems := parseYAMLWorkload(strings.NewReader(tt.input), loadOptions{})
+			got := make([]string, len(problems))
+			for i, p := range problems {
+				got[i] = p.String()
+			}
+			if !reflect.DeepEqual(got, tt.wantProblems) {
+				t.Errorf("parseYAMLWorkload() problems = %q, want %q", got, tt.wantProblems)
+			}
+			if !reflect.DeepEqual(processes, tt.wantProcesses) {
+				t.Errorf("parseYAMLWorkload() processes = %v, want %v", processes, tt.wantProcesses)
+			}
+		})
+	}
+}
+
+func Test_loadWorkload_examplesAgree(t *testing.T) {
+	t.Parallel()
+	load := func(name string) []Process {
+		t.Helper()
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		processes, err := loadWorkload(name, f, loadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return processes
+	}
+	csv, yml := load("example_processes.csv"), load("example_processes.yaml")
+	if !reflect.DeepEqual(yml, csv) {
+		t.Errorf("loadWorkload() YAML example = %v, want the CSV example %v", yml, csv)
+	}
+}